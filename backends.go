@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Target.BackendPolicy values: how the results of a target's urls combine into its state
+const (
+	backendPolicyAllDown = "all_down" // down only when every backend fails (default)
+	backendPolicyAnyDown = "any_down" // down as soon as one backend fails
+	backendPolicyQuorum  = "quorum"   // down when fewer than backend_quorum backends are healthy
+)
+
+// backendHistoryChecks is how many recent checks the detail page shows per backend
+const backendHistoryChecks = 30
+
+// BackendResult is one backend's outcome within a check of a target with urls
+type BackendResult struct {
+	URL          string        `json:"url"`
+	Success      bool          `json:"success"`
+	StatusCode   int           `json:"status_code,omitempty"`
+	ResponseTime time.Duration `json:"response_time"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// backendQuorum returns the healthy backends a quorum target needs to stay up
+func backendQuorum(target *Target) int {
+	if target.BackendQuorum > 0 {
+		return min(target.BackendQuorum, len(target.URLs))
+	}
+	return len(target.URLs)/2 + 1
+}
+
+// backendsDown applies the target's backend_policy to the number of failed backends
+func backendsDown(target *Target, failed int) bool {
+	switch target.BackendPolicy {
+	case backendPolicyAnyDown:
+		return failed > 0
+	case backendPolicyQuorum:
+		return len(target.URLs)-failed < backendQuorum(target)
+	default:
+		return failed == len(target.URLs)
+	}
+}
+
+// checkBackends checks every one of the target's urls with check and combines the results
+// into one. The caller's max_concurrent_checks slot covers one backend; the others run in
+// parallel only while limiter has free slots and otherwise in turn (a nil limiter runs
+// them all in parallel). A down result carries the first failed backend's details and an
+// error listing every failure; an up result carries the slowest healthy backend's.
+func checkBackends(target *Target, limiter *CheckLimiter, check func(backend *Target) *CheckResult) *CheckResult {
+	results := make([]*CheckResult, len(target.URLs))
+	backendTarget := func(i int) *Target {
+		backend := *target
+		backend.URL = target.URLs[i]
+		backend.URLs = nil
+		return &backend
+	}
+	var wg sync.WaitGroup
+	var inline []int
+	for i := range target.URLs {
+		if i == 0 || (limiter != nil && !limiter.TryAcquire()) {
+			inline = append(inline, i)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter != nil {
+				defer limiter.Release()
+			}
+			results[i] = check(backendTarget(i))
+		}()
+	}
+	for _, i := range inline {
+		results[i] = check(backendTarget(i))
+	}
+	wg.Wait()
+
+	backends := make([]BackendResult, len(results))
+	var failures []string
+	var firstFailed, slowestHealthy *CheckResult
+	for i, result := range results {
+		backends[i] = BackendResult{
+			URL:          target.URLs[i],
+			Success:      result.Success,
+			StatusCode:   result.StatusCode,
+			ResponseTime: result.ResponseTime,
+			Error:        result.Error,
+		}
+		if !result.Success {
+			failures = append(failures, fmt.Sprintf("%s: %s", target.URLs[i], result.Error))
+			if firstFailed == nil {
+				firstFailed = result
+			}
+		} else if slowestHealthy == nil || result.ResponseTime > slowestHealthy.ResponseTime {
+			slowestHealthy = result
+		}
+	}
+
+	down := backendsDown(target, len(failures))
+	var combined CheckResult
+	if down {
+		combined = *firstFailed
+		combined.Error = fmt.Sprintf("%d of %d backends down: %s", len(failures), len(results), strings.Join(failures, "; "))
+	} else {
+		combined = *slowestHealthy
+	}
+	combined.Success = !down
+	combined.Backends = backends
+	return &combined
+}
+
+// validateBackends checks a target's urls, backend_policy and backend_quorum
+func validateBackends(target Target) error {
+	if len(target.URLs) == 0 {
+		if target.BackendPolicy != "" || target.BackendQuorum != 0 {
+			return fmt.Errorf("backend_policy and backend_quorum require urls")
+		}
+		return nil
+	}
+	switch target.CheckStrategy {
+	case "webhook", "page-comparison":
+		return fmt.Errorf("urls are not supported by the %s check strategy", target.CheckStrategy)
+	}
+	for _, url := range target.URLs {
+		if err := validateStrategyURL(target.CheckStrategy, url); err != nil {
+			return fmt.Errorf("urls: %q: %v", url, err)
+		}
+	}
+	switch target.BackendPolicy {
+	case "", backendPolicyAllDown, backendPolicyAnyDown, backendPolicyQuorum:
+	default:
+		return fmt.Errorf("invalid backend_policy '%s', must be one of: all_down, any_down, quorum", target.BackendPolicy)
+	}
+	if target.BackendQuorum != 0 {
+		if target.BackendPolicy != backendPolicyQuorum {
+			return fmt.Errorf("backend_quorum requires backend_policy quorum")
+		}
+		if target.BackendQuorum < 1 || target.BackendQuorum > len(target.URLs) {
+			return fmt.Errorf("backend_quorum must be between 1 and the number of urls (%d)", len(target.URLs))
+		}
+	}
+	return nil
+}
+
+// backendsHTML renders the detail page's per-backend breakdown of a target with urls: each
+// backend's latest result, success rate over the kept history and its most recent checks
+func backendsHTML(target *Target, history []CheckHistoryEntry) string {
+	if len(target.URLs) == 0 {
+		return ""
+	}
+	policy := target.BackendPolicy
+	if policy == "" {
+		policy = backendPolicyAllDown
+	}
+	if policy == backendPolicyQuorum {
+		policy = fmt.Sprintf("quorum of %d", backendQuorum(target))
+	}
+
+	rows := ""
+	for _, url := range target.URLs {
+		checks, successes := 0, 0
+		var latest *BackendResult
+		recent := []string{}
+		for i := len(history) - 1; i >= 0; i-- {
+			for _, backend := range history[i].Backends {
+				if backend.URL != url {
+					continue
+				}
+				checks++
+				icon := "❌"
+				if backend.Success {
+					successes++
+					icon = "✅"
+				}
+				if latest == nil {
+					latest = &backend
+				}
+				if len(recent) < backendHistoryChecks {
+					recent = append([]string{icon}, recent...)
+				}
+			}
+		}
+
+		status := "No checks yet"
+		if latest != nil {
+			status = fmt.Sprintf("✅ OK - %s", latest.ResponseTime.Round(time.Millisecond))
+			if !latest.Success {
+				status = "❌ " + html.EscapeString(latest.Error)
+			}
+			status += fmt.Sprintf(" (%d of %d checks succeeded)", successes, checks)
+		}
+		rows += fmt.Sprintf(`<div class="detail-row"><strong>%s:</strong> %s<div>%s</div></div>`,
+			html.EscapeString(url), status, strings.Join(recent, ""))
+	}
+
+	return fmt.Sprintf(`
+	<div class="diagnosis-box recovered">
+		<h2>🧩 Backends (%s)</h2>
+		%s
+	</div>`, policy, rows)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	qc "github.com/bevelwork/quick_color"
+)
+
+// bulkAddResult reports what add --file did with the file's lines
+type bulkAddResult struct {
+	Added   []string // URLs added (or replaced with --on-duplicate overwrite)
+	Merged  []string // URLs merged into an existing target
+	Skipped []string // "line N: reason" for each line that was not added
+}
+
+// handleAddFile adds every URL listed in path. Flags given on the command line apply to
+// each line, and a line's own flags override them.
+func handleAddFile(stateFile, path string, args []string, policy string) {
+	stateManager := NewStateManager(stateFile)
+	if err := stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load existing state: %v", err)
+	}
+
+	result, err := addTargetsFromFile(stateManager, path, args, policy)
+	if err != nil {
+		fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	for _, url := range result.Added {
+		fmt.Printf("  %s %s\n", qc.Colorize("+", qc.ColorGreen), url)
+	}
+	for _, url := range result.Merged {
+		fmt.Printf("  %s %s (merged)\n", qc.Colorize("~", qc.ColorYellow), url)
+	}
+	for _, reason := range result.Skipped {
+		fmt.Printf("  %s %s\n", qc.Colorize("-", qc.ColorRed), reason)
+	}
+	fmt.Printf("%s Added %d, merged %d, skipped %d target(s) from %s\n", qc.Colorize("✅ Success:", qc.ColorGreen),
+		len(result.Added), len(result.Merged), len(result.Skipped), path)
+	if len(result.Skipped) > 0 {
+		os.Exit(1)
+	}
+}
+
+// addTargetsFromFile adds one target per line of path: a URL followed by optional add
+// flags (e.g. `https://api.example.com/health --method HEAD --threshold 60`). Blank lines
+// and lines starting with # are ignored. A line that is invalid or clashes with a stored
+// target under the duplicate policy is skipped without stopping the rest.
+func addTargetsFromFile(stateManager *StateManager, path string, flags []string, policy string) (bulkAddResult, error) {
+	var result bulkAddResult
+	file, err := os.Open(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	defer file.Close()
+
+	policy = cmp.Or(policy, stateManager.GetSettings().DuplicateTargets, DuplicateTargetsError)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		skip := func(format string, args ...any) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("line %d: ", lineNumber)+fmt.Sprintf(format, args...))
+		}
+		fields, err := splitArgs(line)
+		if err != nil {
+			skip("%v", err)
+			continue
+		}
+		url := fields[0]
+		// The line's flags come first so they win over the command line's
+		opts := parseAddOptions(append(fields[1:], flags...))
+
+		if _, exists := stateManager.GetTarget(url); exists && policy == DuplicateTargetsMerge {
+			if _, err := stateManager.AddTargetWithPolicy(opts.mergeUpdate(url), policy); err != nil {
+				skip("%s: %v", url, err)
+				continue
+			}
+			result.Merged = append(result.Merged, url)
+			continue
+		}
+
+		target := opts.newTarget(url)
+		if err := validateTargets(map[string]Target{url: target}, stateManager); err != nil {
+			skip("%v", err)
+			continue
+		}
+		if _, err := stateManager.AddTargetWithPolicy(target, policy); err != nil {
+			var duplicate *DuplicateTargetError
+			if errors.As(err, &duplicate) {
+				skip("%s already exists", url)
+			} else {
+				skip("%s: %v", url, err)
+			}
+			continue
+		}
+		result.Added = append(result.Added, url)
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return result, nil
+}
+
+// splitArgs splits a line into shell-like words; single or double quotes keep spaces in a
+// word, e.g. --header "Authorization: Bearer token"
+func splitArgs(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	inWord := false
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the @-shorthands accepted in place of five cron fields
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a parsed five-field cron expression (minute hour day-of-month
+// month day-of-week) evaluated in the server's local time zone
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // bit n set = value n allowed
+	domAny, dowAny                bool   // field was "*", for the day-matching rule
+}
+
+// ParseCronSchedule parses an expression such as "0 9 * * 1-5". Fields accept *,
+// numbers, ranges (1-5), lists (1,15) and steps (*/15, 0-30/10); month and
+// day-of-week also accept names (jan, mon), and 7 means Sunday like 0.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	sched := &CronSchedule{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	var err error
+	if sched.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron minute: %v", err)
+	}
+	if sched.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron hour: %v", err)
+	}
+	if sched.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron day-of-month: %v", err)
+	}
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	if sched.month, err = parseCronField(fields[3], 1, 12, months); err != nil {
+		return nil, fmt.Errorf("cron month: %v", err)
+	}
+	days := []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	if sched.dow, err = parseCronField(fields[4], 0, 7, days); err != nil {
+		return nil, fmt.Errorf("cron day-of-week: %v", err)
+	}
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1 // 7 is Sunday too
+	}
+	return sched, nil
+}
+
+// parseCronField turns one comma-separated field into a bitmask of allowed values.
+// names, when given, are accepted for the values starting at min.
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	value := func(s string) (int, error) {
+		for i, name := range names {
+			if strings.EqualFold(s, name) {
+				return min + i, nil
+			}
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+		if n < min || n > max {
+			return 0, fmt.Errorf("value %d out of range %d-%d", n, min, max)
+		}
+		return n, nil
+	}
+
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if before, after, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", after)
+			}
+			rangePart, step = before, n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = value(from); err != nil {
+				return 0, err
+			}
+			if hi, err = value(to); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("range %q runs backwards", rangePart)
+			}
+		default:
+			n, err := value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo = n
+			if step == 1 {
+				hi = n
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << v
+		}
+	}
+	return mask, nil
+}
+
+// matchesDay applies cron's day rule: when both day-of-month and day-of-week are
+// restricted, a day matching either one runs
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := c.dom&(1<<t.Day()) != 0
+	dowMatch := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first scheduled minute strictly after t, or the zero time if the
+// expression never fires (e.g. "0 0 31 2 *")
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
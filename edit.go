@@ -3,10 +3,18 @@ package main
 import (
 	"fmt"
 	"log"
+	"math"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 	"unicode/utf8"
 
 	qc "github.com/bevelwork/quick_color"
@@ -127,6 +135,13 @@ func handleEditTargets(stateFile string) {
 		return
 	}
 
+	// Merge include: files and the defaults: block so the result is what gets validated
+	modifiedData, err = resolveIncludesAndDefaults(modifiedData, ".")
+	if err != nil {
+		fmt.Printf("%s Failed to resolve includes/defaults: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		return
+	}
+
 	// Parse the modified configuration (simplified structure)
 	var targetsData map[string]any
 	if err := yaml.Unmarshal(modifiedData, &targetsData); err != nil {
@@ -161,7 +176,7 @@ func handleEditTargets(stateFile string) {
 						target.Method = method
 						fields.Method = true
 					}
-					if threshold, ok := targetMap["threshold"].(int); ok {
+					if threshold, ok := yamlInt(targetMap["threshold"]); ok {
 						target.Threshold = threshold
 						fields.Threshold = true
 					}
@@ -178,10 +193,10 @@ func handleEditTargets(stateFile string) {
 						if enabled, ok := sizeAlerts["enabled"].(bool); ok {
 							target.SizeAlerts.Enabled = enabled
 						}
-						if historySize, ok := sizeAlerts["history_size"].(int); ok {
+						if historySize, ok := yamlInt(sizeAlerts["history_size"]); ok {
 							target.SizeAlerts.HistorySize = historySize
 						}
-						if threshold, ok := sizeAlerts["threshold"].(float64); ok {
+						if threshold, ok := yamlFloat(sizeAlerts["threshold"]); ok {
 							target.SizeAlerts.Threshold = threshold
 						}
 					}
@@ -189,7 +204,7 @@ func handleEditTargets(stateFile string) {
 						target.CheckStrategy = checkStrategy
 						fields.CheckStrategy = true
 					}
-					if duration, ok := targetMap["duration"].(int); ok {
+					if duration, ok := yamlInt(targetMap["duration"]); ok {
 						target.Duration = duration
 					}
 
@@ -231,7 +246,7 @@ func handleEditTargets(stateFile string) {
 						target.Method = method
 						fields.Method = true
 					}
-					if threshold, ok := targetMap["threshold"].(int); ok {
+					if threshold, ok := yamlInt(targetMap["threshold"]); ok {
 						target.Threshold = threshold
 						fields.Threshold = true
 					}
@@ -248,10 +263,10 @@ func handleEditTargets(stateFile string) {
 						if enabled, ok := sizeAlerts["enabled"].(bool); ok {
 							target.SizeAlerts.Enabled = enabled
 						}
-						if historySize, ok := sizeAlerts["history_size"].(int); ok {
+						if historySize, ok := yamlInt(sizeAlerts["history_size"]); ok {
 							target.SizeAlerts.HistorySize = historySize
 						}
-						if threshold, ok := sizeAlerts["threshold"].(float64); ok {
+						if threshold, ok := yamlFloat(sizeAlerts["threshold"]); ok {
 							target.SizeAlerts.Threshold = threshold
 						}
 					}
@@ -259,7 +274,7 @@ func handleEditTargets(stateFile string) {
 						target.CheckStrategy = checkStrategy
 						fields.CheckStrategy = true
 					}
-					if duration, ok := targetMap["duration"].(int); ok {
+					if duration, ok := yamlInt(targetMap["duration"]); ok {
 						target.Duration = duration
 					}
 
@@ -311,7 +326,7 @@ func handleEditTargets(stateFile string) {
 			if !fields.Headers && len(target.Headers) == 0 && existing.Headers != nil {
 				target.Headers = existing.Headers
 			}
-			if !fields.Threshold && target.Threshold == 0 {
+			if !fields.Threshold && target.Threshold == 0 && target.DownAfterFailures == 0 {
 				target.Threshold = existing.Threshold
 			}
 			if !fields.StatusCodes && len(target.StatusCodes) == 0 && len(existing.StatusCodes) > 0 {
@@ -342,6 +357,7 @@ func handleEditTargets(stateFile string) {
 			if target.Name == "" {
 				target.Name = existing.Name
 			}
+			preserveTargetOptions(&target, existing, fields)
 		}
 
 		if err := stateManager.AddTarget(target); err != nil {
@@ -389,10 +405,6 @@ func handleEditTargets(stateFile string) {
 		if strings.TrimSpace(displayMethod) == "" {
 			displayMethod = "GET"
 		}
-		displayThreshold := target.Threshold
-		if displayThreshold == 0 {
-			displayThreshold = 30
-		}
 		displayCheck := target.CheckStrategy
 		if strings.TrimSpace(displayCheck) == "" {
 			displayCheck = "http"
@@ -405,8 +417,8 @@ func handleEditTargets(stateFile string) {
 				displayAlerts = "console"
 			}
 		}
-		fmt.Printf("     Method: %s, Threshold: %ds, Check: %s, Alert: %s\n",
-			displayMethod, displayThreshold, displayCheck, displayAlerts)
+		fmt.Printf("     Method: %s, Threshold: %s, Check: %s, Alert: %s\n",
+			displayMethod, describeThreshold(target), displayCheck, displayAlerts)
 		i++
 	}
 
@@ -537,12 +549,54 @@ func addEditCommentsForSimplified(data []byte, availableAlerts []string) []byte
 		{0, "All available fields:", ""},
 		{0, "  method: GET", "# HTTP method (http only)"},
 		{0, "  headers: {}", "# custom headers (http only)"},
+		{0, "  body: '{\"query\": \"{ __typename }\"}'", "# request body, e.g. for POST (http only)"},
 		{0, "  threshold: 30", "# alert threshold in seconds"},
+		{0, "  interval: 60", "# seconds between checks (overrides check_interval)"},
+		{0, "  retries: 2", "# re-checks with backoff before a failure counts, max 5"},
 		{0, "  status_codes: ['*']", "# acceptable codes (http only)"},
 		{0, "  ports: [22, 80, 443]", "# ports to check (tcp only)"},
+		{0, "  grpc_service: my.package.Service", "# service in the health check (grpc only)"},
+		{0, "  grpc_tls: true", "# dial with TLS instead of plaintext (grpc only)"},
+		{0, "  grpc_discovery: reflection", "# find the health service via reflection, else check connectivity (grpc only)"},
+		{0, "  ping_count: 3", "# echo requests per check, max 10 (ping only)"},
+		{0, "  max_packet_loss: 34", "# % loss tolerated before failing (ping only)"},
 		{0, "  visual_threshold: 5.0", "# % difference (page-comparison only)"},
 		{0, "  screenshot_path: ./screenshots", "# screenshot storage (page-comparison only)"},
 		{0, "  duration: 300", "# auto-recovery seconds (webhook only)"},
+		{0, "  client_cert_file: /etc/quick_watch/client.crt", "# mutual TLS cert, with client_key_file (http only)"},
+		{0, "  client_key_file: /etc/quick_watch/client.key", "# key for client_cert_file (http only)"},
+		{0, "  max_redirects: 10", "# redirects followed before failing (http only)"},
+		{0, "  follow_redirects: false", "# judge the 3xx itself against status_codes (http only)"},
+		{0, "  timeout: 10", "# seconds before a check times out (http/grpc only)"},
+		{0, "  body_match: 'status: ok'", "# required body text, /regex/ for a pattern (http only)"},
+		{0, "  phase_thresholds: {tls: 500, ttfb: 2000}", "# fail when a latency phase exceeds ms (http only)"},
+		{0, "  max_response_time: 1500", "# ms; slower successful checks raise a SLOW alert"},
+		{0, "  slow_alerts: [slack-perf]", "# alerts that get SLOW alerts (default: alerts)"},
+		{0, "  alert_routes: {down: [pagerduty], size: [slack]}", "# alerts per event: down, recovery, slow, size, content"},
+		{0, "  content_alerts: {enabled: true}", "# alert when the response body changes (http only)"},
+		{0, "  max_body_read_kb: 10", "# KB of body inspected (http only)"},
+		{0, "  max_body_store_kb: 10", "# KB of body kept in history (http only)"},
+		{0, "  severity: critical", "# critical, warning or info (critical-only paging)"},
+		{0, "  tags: [payments, team-a]", "# labels for dashboard/API filters and status_report.groups"},
+		{0, "  depends_on: [Auth Service]", "# suppress alerts while these targets are down"},
+		{0, "  recovery_threshold: 3", "# passing checks in a row before recovery (default: settings value)"},
+		{0, "  recovery_cooldown_seconds: 60", "# hold DOWN alerts this long after an ALL CLEAR (default: settings value)"},
+		{0, "  check_history_size: 5000", "# checks kept in history (default: settings value)"},
+		{0, "  paused: true", "# skip checks and alerts, keeping config and history"},
+		{0, "  cookies: {session: ${SESSION_TOKEN}}", "# cookies sent with checks (http only)"},
+		{0, "  extract: {code: $.error.code}", "# JSON values for alert templates (http only)"},
+		{0, "  json_assertions: [{path: $.status, equals: ok}]", "# JSON values the body must hold (http only)"},
+		{0, "  alert_message_template: '{{.Extracted.code}}'", "# replaces the default DOWN alert text"},
+		{0, "  escalation: [{after_minutes: 15, alerts: [pagerduty]}]", "# page more alerts while unacknowledged"},
+		{0, "  ip_version: 6", "# dial only IPv4 (4) or IPv6 (6), default auto (http/tcp/grpc only)"},
+		{0, "  failure_count: 3", "# down after this many failed checks in a row, instead of threshold seconds"},
+		{0, "  slack_channel: \"#payments-oncall\"", "# post this target's Slack messages here"},
+		{0, "  slack_mention: here", "# mention in Slack DOWN alerts: here, channel, everyone or a user ID"},
+		{0, "  expected_content_type: application/json", "# fail when the response Content-Type differs (http only)"},
+		{0, "  callback: {url: https://ci.example.com/hook, method: POST}", "# request sent on trigger and recovery (webhook only)"},
+		{0, "  urls: [https://a.example.com, https://b.example.com]", "# backends checked together as this one target"},
+		{0, "  backend_policy: quorum", "# all_down (default), any_down or quorum"},
+		{0, "  backend_quorum: 2", "# healthy backends needed with quorum (default: majority)"},
 		{0, "", ""},
 	})
 	commentedLines := append(rendered, lines...)
@@ -557,6 +611,16 @@ func validateYAML(data []byte) error {
 
 // validateTargets validates target configurations without applying defaults
 func validateTargets(targets map[string]Target, stateManager *StateManager) error {
+	var alerts map[string]NotifierConfig
+	if stateManager != nil {
+		alerts = stateManager.GetAlerts()
+	}
+	return validateTargetsWithAlerts(targets, alerts)
+}
+
+// validateTargetsWithAlerts validates targets against the given notifiers, for callers
+// that already hold the state manager's lock
+func validateTargetsWithAlerts(targets map[string]Target, alerts map[string]NotifierConfig) error {
 	validHTTPMethods := map[string]bool{
 		"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true,
 		"HEAD": true, "OPTIONS": true, "TRACE": true, "CONNECT": true,
@@ -566,6 +630,8 @@ func validateTargets(targets map[string]Target, stateManager *StateManager) erro
 		"http":            true,
 		"webhook":         true,
 		"tcp":             true,
+		"grpc":            true,
+		"ping":            true,
 		"page-comparison": true,
 	}
 
@@ -575,12 +641,9 @@ func validateTargets(targets map[string]Target, stateManager *StateManager) erro
 	validAlerts["console"] = true
 
 	// Add alert-based alerts
-	if stateManager != nil {
-		alerts := stateManager.GetAlerts()
-		for name, alert := range alerts {
-			if alert.Enabled {
-				validAlerts[name] = true
-			}
+	for name, alert := range alerts {
+		if alert.Enabled {
+			validAlerts[name] = true
 		}
 	}
 
@@ -593,12 +656,9 @@ func validateTargets(targets map[string]Target, stateManager *StateManager) erro
 			return fmt.Errorf("target %s: name is REQUIRED and cannot be empty", url)
 		}
 
-		// Validate URL format (basic check) - skip for webhook and tcp targets
-		// page-comparison requires http:// or https:// URLs
-		if target.CheckStrategy != "webhook" && target.CheckStrategy != "tcp" {
-			if !strings.HasPrefix(target.URL, "http://") && !strings.HasPrefix(target.URL, "https://") {
-				return fmt.Errorf("target %s: url must start with http:// or https://", url)
-			}
+		// Validate URL format for the check strategy
+		if err := validateStrategyURL(target.CheckStrategy, target.URL); err != nil {
+			return fmt.Errorf("target %s: %v", url, err)
 		}
 
 		// Validate TCP-specific fields
@@ -613,6 +673,40 @@ func validateTargets(targets map[string]Target, stateManager *StateManager) erro
 			}
 		}
 
+		for _, pattern := range target.StatusCodes {
+			if _, err := matchStatusCodePattern(0, pattern); err != nil {
+				return fmt.Errorf("target %s: status_codes: %v", url, err)
+			}
+		}
+
+		if target.Body != "" && target.CheckStrategy != "" && target.CheckStrategy != "http" {
+			return fmt.Errorf("target %s: body is only supported by the http check strategy", url)
+		}
+
+		// Validate gRPC-specific fields
+		if target.CheckStrategy != "grpc" && (target.GRPCService != "" || target.GRPCTLS || target.GRPCDiscovery != "") {
+			return fmt.Errorf("target %s: grpc_service, grpc_tls and grpc_discovery require check_strategy: grpc", url)
+		}
+		switch target.GRPCDiscovery {
+		case "", GRPCDiscoveryService:
+		case GRPCDiscoveryReflection:
+			if target.GRPCService != "" {
+				return fmt.Errorf("target %s: grpc_service can't be combined with grpc_discovery: reflection", url)
+			}
+		default:
+			return fmt.Errorf("target %s: invalid grpc_discovery '%s', must be one of: service, reflection", url, target.GRPCDiscovery)
+		}
+
+		// Validate ping-specific fields
+		if target.CheckStrategy == "ping" {
+			if target.PingCount < 0 || target.PingCount > maxPingCount {
+				return fmt.Errorf("target %s: ping_count must be between 1 and %d, got %d", url, maxPingCount, target.PingCount)
+			}
+			if target.MaxPacketLoss < 0 || target.MaxPacketLoss > 100 {
+				return fmt.Errorf("target %s: max_packet_loss must be between 0 and 100, got %.2f", url, target.MaxPacketLoss)
+			}
+		}
+
 		// Validate page-comparison specific fields
 		if target.CheckStrategy == "page-comparison" {
 			if target.VisualThreshold < 0 || target.VisualThreshold > 100 {
@@ -629,10 +723,250 @@ func validateTargets(targets map[string]Target, stateManager *StateManager) erro
 		if target.Threshold < 0 {
 			return fmt.Errorf("target %s: threshold must be a positive integer, got %d", url, target.Threshold)
 		}
+		if target.DownAfterFailures < 0 {
+			return fmt.Errorf("target %s: failure_count must be a positive integer, got %d", url, target.DownAfterFailures)
+		}
+		if target.DownAfterFailures > 0 && target.Threshold > 0 {
+			return fmt.Errorf("target %s: set either threshold (seconds) or failure_count (checks), not both", url)
+		}
+		if target.DownAfterFailures > 0 && target.CheckStrategy == "webhook" {
+			return fmt.Errorf("target %s: failure_count does not apply to webhook targets", url)
+		}
+		if target.Interval < 0 {
+			return fmt.Errorf("target %s: interval must be at least 1 second, got %d", url, target.Interval)
+		}
+		if target.CABundleFile != "" {
+			if _, err := loadCABundle(target.CABundleFile); err != nil {
+				return fmt.Errorf("target %s: %v", url, err)
+			}
+		}
+		if target.ClientCertFile != "" || target.ClientKeyFile != "" {
+			if target.CheckStrategy != "" && target.CheckStrategy != "http" {
+				return fmt.Errorf("target %s: client_cert_file is only supported by the http check strategy", url)
+			}
+			if _, err := loadClientCertificate(target.ClientCertFile, target.ClientKeyFile); err != nil {
+				return fmt.Errorf("target %s: %v", url, err)
+			}
+		}
+		if target.MaxRedirects < 0 {
+			return fmt.Errorf("target %s: max_redirects cannot be negative, got %d", url, target.MaxRedirects)
+		}
+		if target.FollowRedirects != nil && !*target.FollowRedirects && target.CheckStrategy != "" && target.CheckStrategy != "http" {
+			return fmt.Errorf("target %s: follow_redirects is only supported by the http check strategy", url)
+		}
+		if source, isRegexp := bodyMatchRegexp(target.BodyMatch); isRegexp {
+			if _, err := regexp.Compile(source); err != nil {
+				return fmt.Errorf("target %s: invalid body_match regex %s: %v", url, target.BodyMatch, err)
+			}
+		}
+		if target.Timeout < 0 {
+			return fmt.Errorf("target %s: timeout must be a positive number of seconds, got %d", url, target.Timeout)
+		}
+		if target.Retries < 0 || target.Retries > maxCheckRetries {
+			return fmt.Errorf("target %s: retries must be between 0 and %d, got %d", url, maxCheckRetries, target.Retries)
+		}
+		if target.RetryOnFailure < 0 || target.RetryOnFailure > maxCheckRetries {
+			return fmt.Errorf("target %s: retry_on_failure (deprecated, use retries) must be between 0 and %d, got %d", url, maxCheckRetries, target.RetryOnFailure)
+		}
+		if target.Retries > 0 && target.RetryOnFailure > 0 {
+			return fmt.Errorf("target %s: set either retries or its deprecated alias retry_on_failure, not both", url)
+		}
+		for phase, limit := range target.PhaseThresholds {
+			if !slices.Contains(httpTimingPhases, phase) {
+				return fmt.Errorf("target %s: unknown phase_thresholds phase '%s', must be one of: %s", url, phase, strings.Join(httpTimingPhases, ", "))
+			}
+			if limit <= 0 {
+				return fmt.Errorf("target %s: phase_thresholds.%s must be a positive number of milliseconds, got %d", url, phase, limit)
+			}
+		}
+		if target.MaxResponseTime < 0 {
+			return fmt.Errorf("target %s: max_response_time must be a positive number of milliseconds, got %d", url, target.MaxResponseTime)
+		}
+		if len(target.SlowAlerts) > 0 && target.MaxResponseTime == 0 {
+			return fmt.Errorf("target %s: slow_alerts requires max_response_time", url)
+		}
+		for event, names := range target.AlertRoutes {
+			if !slices.Contains(alertRouteEvents, event) {
+				return fmt.Errorf("target %s: unknown alert_routes event '%s', must be one of: %s", url, event, strings.Join(alertRouteEvents, ", "))
+			}
+			if len(names) == 0 || slices.Contains(names, "") {
+				return fmt.Errorf("target %s: alert_routes.%s must list at least one alert name", url, event)
+			}
+		}
+		if len(target.AlertRoutes["slow"]) > 0 && target.MaxResponseTime == 0 {
+			return fmt.Errorf("target %s: alert_routes.slow requires max_response_time", url)
+		}
+		if target.ContentAlerts != nil && target.ContentAlerts.Enabled {
+			if target.CheckStrategy != "" && target.CheckStrategy != "http" {
+				return fmt.Errorf("target %s: content_alerts is only supported by the http check strategy", url)
+			}
+			if target.ContentAlerts.HistorySize < 0 {
+				return fmt.Errorf("target %s: content_alerts.history_size cannot be negative, got %d", url, target.ContentAlerts.HistorySize)
+			}
+		}
+		if err := validateEscalation(target.Escalation); err != nil {
+			return fmt.Errorf("target %s: %w", url, err)
+		}
+		if target.IPVersion != "" && target.IPVersion != "auto" {
+			if target.IPVersion != "4" && target.IPVersion != "6" {
+				return fmt.Errorf("target %s: ip_version must be auto, 4 or 6, got '%s'", url, target.IPVersion)
+			}
+			if target.CheckStrategy != "" && target.CheckStrategy != "http" && target.CheckStrategy != "tcp" && target.CheckStrategy != "grpc" {
+				return fmt.Errorf("target %s: ip_version is only supported by the http, tcp and grpc check strategies", url)
+			}
+		}
+		if target.SlackChannel != "" && !slackChannelPattern.MatchString(target.SlackChannel) {
+			return fmt.Errorf("target %s: slack_channel must be '#name' or a channel ID, got '%s'", url, target.SlackChannel)
+		}
+		if target.SlackMention != "" && !slackMentionPattern.MatchString(target.SlackMention) {
+			return fmt.Errorf("target %s: slack_mention must be here, channel, everyone or a user ID, got '%s'", url, target.SlackMention)
+		}
+		if target.MaxBodyReadKB < 0 || target.MaxBodyStoreKB < 0 {
+			return fmt.Errorf("target %s: max_body_read_kb and max_body_store_kb cannot be negative", url)
+		}
+		if target.MaxBodyReadKB > 0 && target.MaxBodyStoreKB > target.MaxBodyReadKB {
+			return fmt.Errorf("target %s: max_body_store_kb (%d) cannot exceed max_body_read_kb (%d)", url, target.MaxBodyStoreKB, target.MaxBodyReadKB)
+		}
+		if target.InitialGraceSeconds < 0 {
+			return fmt.Errorf("target %s: initial_grace_seconds cannot be negative, got %d", url, target.InitialGraceSeconds)
+		}
+		if target.RecoveryThreshold < 0 {
+			return fmt.Errorf("target %s: recovery_threshold cannot be negative, got %d", url, target.RecoveryThreshold)
+		}
+		if target.RecoveryCooldownSeconds < 0 {
+			return fmt.Errorf("target %s: recovery_cooldown_seconds cannot be negative, got %d", url, target.RecoveryCooldownSeconds)
+		}
+		if target.CheckHistorySize < 0 || target.CheckHistorySize > maxCheckHistorySize {
+			return fmt.Errorf("target %s: check_history_size must be between 0 and %d, got %d", url, maxCheckHistorySize, target.CheckHistorySize)
+		}
+		switch target.Severity {
+		case "", "critical", "warning", "info":
+		default:
+			return fmt.Errorf("target %s: invalid severity '%s', must be one of: critical, warning, info", url, target.Severity)
+		}
+		for name, path := range target.Extract {
+			if !strings.HasPrefix(strings.TrimSpace(path), "$") {
+				return fmt.Errorf("target %s: extract %s: JSON path must start with '$', got %q", url, name, path)
+			}
+		}
+		for _, assertion := range target.JSONAssertions {
+			if !strings.HasPrefix(strings.TrimSpace(assertion.Path), "$") {
+				return fmt.Errorf("target %s: json_assertions: JSON path must start with '$', got %q", url, assertion.Path)
+			}
+		}
+		if len(target.JSONAssertions) > 0 && target.CheckStrategy != "" && target.CheckStrategy != "http" {
+			return fmt.Errorf("target %s: json_assertions are only supported by the http check strategy", url)
+		}
+		if target.ExpectedContentType != "" && target.CheckStrategy != "" && target.CheckStrategy != "http" {
+			return fmt.Errorf("target %s: expected_content_type is only supported by the http check strategy", url)
+		}
+		if target.Callback != nil {
+			if target.CheckStrategy != "webhook" {
+				return fmt.Errorf("target %s: callback is only supported by the webhook check strategy", url)
+			}
+			if err := validateWebhookCallback(target.Callback); err != nil {
+				return fmt.Errorf("target %s: callback: %v", url, err)
+			}
+		}
+		if err := validateBackends(target); err != nil {
+			return fmt.Errorf("target %s: %v", url, err)
+		}
+		for name, value := range target.Cookies {
+			if err := (&http.Cookie{Name: name, Value: value}).Valid(); err != nil {
+				return fmt.Errorf("target %s: invalid cookie %q: %v", url, name, err)
+			}
+		}
+		if target.AlertMessageTemplate != "" {
+			if _, err := template.New("alert").Parse(target.AlertMessageTemplate); err != nil {
+				return fmt.Errorf("target %s: invalid alert_message_template: %v", url, err)
+			}
+		}
+
+		for _, tag := range target.Tags {
+			if strings.TrimSpace(tag) == "" || strings.Contains(tag, ",") {
+				return fmt.Errorf("target %s: tags must be non-empty and cannot contain commas, got %q", url, tag)
+			}
+		}
 
 		// Validate check strategy if provided (don't apply default, just validate)
 		if target.CheckStrategy != "" && !validCheckStrategies[target.CheckStrategy] {
-			return fmt.Errorf("target %s: invalid check_strategy '%s', must be one of: http, tcp, webhook, page-comparison", url, target.CheckStrategy)
+			return fmt.Errorf("target %s: invalid check_strategy '%s', must be one of: http, tcp, grpc, ping, webhook, page-comparison", url, target.CheckStrategy)
+		}
+	}
+	return validateDependencies(targets)
+}
+
+// validateStrategyURL checks that url has the form the check strategy dials: host:port for
+// grpc, a host name or IP address for tcp and ping, and an http(s) URL for the rest.
+// Webhook targets are never dialed, so any url names them.
+func validateStrategyURL(strategy, url string) error {
+	switch strategy {
+	case "webhook":
+	case "grpc":
+		if _, port, err := net.SplitHostPort(url); err != nil || port == "" {
+			return fmt.Errorf("url must be host:port for grpc check strategy, got %q", url)
+		}
+	case "tcp", "ping":
+		if url == "" || strings.Contains(url, "/") || (strings.Contains(url, ":") && net.ParseIP(url) == nil) {
+			return fmt.Errorf("url must be a host name or IP address for %s check strategy, got %q", strategy, url)
+		}
+	default:
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return fmt.Errorf("url must start with http:// or https://")
+		}
+	}
+	return nil
+}
+
+// validateDependencies checks that depends_on names exist and do not form a cycle
+func validateDependencies(targets map[string]Target) error {
+	byName := make(map[string]Target, len(targets))
+	for _, target := range targets {
+		byName[target.Name] = target
+	}
+	for _, target := range targets {
+		for _, dep := range target.DependsOn {
+			if dep == target.Name {
+				return fmt.Errorf("target %s: depends_on cannot reference itself", target.Name)
+			}
+			if _, exists := byName[dep]; !exists {
+				return fmt.Errorf("target %s: depends_on references unknown target '%s'", target.Name, dep)
+			}
+		}
+	}
+
+	// Depth-first search; a target seen again while still on the stack closes a cycle
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	marks := make(map[string]int, len(byName))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case inProgress:
+			return fmt.Errorf("depends_on cycle: %s", strings.Join(append(path, name), " -> "))
+		case done:
+			return nil
+		}
+		marks[name] = inProgress
+		for _, dep := range byName[name].DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = done
+		return nil
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -689,43 +1023,139 @@ func editSettings(stateManager *StateManager) {
 	}
 
 	// Extract settings
+	settings := parseSettingsData(settingsData)
+
+	// Validate settings
+	if err := validateSettings(settings); err != nil {
+		fmt.Printf("%s Invalid settings: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		fmt.Printf("%s Please fix the validation errors and try again.\n", qc.Colorize("💡 Tip:", qc.ColorYellow))
+		return
+	}
+
+	// Update settings in state manager
+	if err := stateManager.UpdateSettings(settings); err != nil {
+		fmt.Printf("%s Failed to update settings: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		return
+	}
+
+	fmt.Printf("%s Settings updated successfully!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
+}
+
+// parseSettingsData builds ServerSettings from edited settings YAML, applying defaults for missing keys
+func parseSettingsData(settingsData map[string]any) ServerSettings {
 	settings := ServerSettings{
-		WebhookPort:      8080,
-		WebhookPath:      "/webhook",
-		CheckInterval:    5,
-		DefaultThreshold: 30,
-		Startup: StartupConfig{
-			Enabled: true,
-			Alerts:  []string{"console"},
-		},
+		WebhookPort:             8080,
+		WebhookPath:             "/webhook",
+		CheckInterval:           5,
+		DefaultThreshold:        30,
+		Startup:                 StartupConfig{Enabled: true, Alerts: []string{"console"}},
 		AcknowledgementsEnabled: false,
 	}
-
-	if webhookPort, ok := settingsData["webhook_port"].(int); ok {
-		settings.WebhookPort = webhookPort
+	if v, ok := yamlInt(settingsData["webhook_port"]); ok {
+		settings.WebhookPort = v
 	}
-	if webhookPath, ok := settingsData["webhook_path"].(string); ok {
-		settings.WebhookPath = webhookPath
+	if v, ok := settingsData["webhook_path"].(string); ok {
+		settings.WebhookPath = v
 	}
-	if serverAddress, ok := settingsData["server_address"].(string); ok {
-		settings.ServerAddress = serverAddress
+	if v, ok := settingsData["listen_host"].(string); ok {
+		settings.ListenHost = v
 	}
-	if checkInterval, ok := settingsData["check_interval"].(int); ok {
-		settings.CheckInterval = checkInterval
+	if v, ok := settingsData["server_address"].(string); ok {
+		settings.ServerAddress = v
 	}
-	if defaultThreshold, ok := settingsData["default_threshold"].(int); ok {
-		settings.DefaultThreshold = defaultThreshold
+	if v, ok := yamlInt(settingsData["check_interval"]); ok {
+		settings.CheckInterval = v
 	}
-	if acksEnabled, ok := settingsData["acknowledgements_enabled"].(bool); ok {
-		settings.AcknowledgementsEnabled = acksEnabled
+	if v, ok := yamlInt(settingsData["default_threshold"]); ok {
+		settings.DefaultThreshold = v
+	}
+	if v, ok := settingsData["acknowledgements_enabled"].(bool); ok {
+		settings.AcknowledgementsEnabled = v
+	}
+	if v, ok := settingsData["require_ack_for_autoresolve"].(bool); ok {
+		settings.RequireAckForAutoresolve = v
+	}
+	if v, ok := yamlInt(settingsData["shutdown_timeout_seconds"]); ok {
+		settings.ShutdownTimeoutSeconds = v
+	}
+	if v, ok := settingsData["ca_bundle_file"].(string); ok {
+		settings.CABundleFile = v
+	}
+	if v, ok := yamlInt(settingsData["ack_ttl"]); ok {
+		settings.AckTTL = v
+	}
+	if v, ok := yamlInt(settingsData["recovery_threshold"]); ok {
+		settings.RecoveryThreshold = v
+	}
+	if v, ok := yamlInt(settingsData["recovery_cooldown_seconds"]); ok {
+		settings.RecoveryCooldownSeconds = v
+	}
+	if v, ok := yamlInt(settingsData["notifier_failure_threshold"]); ok {
+		settings.NotifierFailureThreshold = v
+	}
+	if v, ok := yamlInt(settingsData["initial_grace_seconds"]); ok {
+		settings.InitialGraceSeconds = v
+	}
+	if v, ok := yamlInt(settingsData["history_retention_hours"]); ok {
+		settings.HistoryRetentionHours = v
+	}
+	if v, ok := yamlInt(settingsData["check_history_size"]); ok {
+		settings.CheckHistorySize = v
+	}
+	if v, ok := yamlInt(settingsData["max_concurrent_checks"]); ok {
+		settings.MaxConcurrentChecks = v
+	}
+	if v, ok := yamlInt(settingsData["trigger_cooldown_seconds"]); ok {
+		settings.TriggerCooldownSeconds = v
+	}
+	if headers, ok := settingsData["default_headers"].(map[string]any); ok {
+		settings.DefaultHeaders = make(map[string]string, len(headers))
+		for name, value := range headers {
+			settings.DefaultHeaders[name] = fmt.Sprint(value)
+		}
+	}
+	if v, ok := yamlInt(settingsData["max_body_store_kb"]); ok {
+		settings.MaxBodyStoreKB = v
+	}
+	if v, ok := settingsData["capture_all_bodies"].(bool); ok {
+		settings.CaptureAllBodies = v
+	}
+	if v, ok := settingsData["proxy_url"].(string); ok {
+		settings.ProxyURL = v
+	}
+	switch hosts := settingsData["no_proxy"].(type) {
+	case string:
+		settings.NoProxy = strings.Split(hosts, ",")
+	case []any:
+		settings.NoProxy = make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			if str, ok := host.(string); ok {
+				settings.NoProxy = append(settings.NoProxy, str)
+			}
+		}
+	}
+	if v, ok := settingsData["duplicate_targets"].(string); ok {
+		settings.DuplicateTargets = v
+	}
+	if v, ok := settingsData["otlp_enabled"].(bool); ok {
+		settings.OTLPEnabled = v
+	}
+	if v, ok := settingsData["otlp_endpoint"].(string); ok {
+		settings.OTLPEndpoint = v
+	}
+	if v, ok := settingsData["log_format"].(string); ok {
+		settings.LogFormat = v
+	}
+	if v, ok := settingsData["timezone"].(string); ok {
+		settings.Timezone = v
+	}
+	if v, ok := settingsData["debug"].(bool); ok {
+		settings.Debug = v
 	}
-
-	// Parse startup configuration
 	if startupData, ok := settingsData["startup"].(map[string]any); ok {
-		if enabled, ok := startupData["enabled"].(bool); ok {
-			settings.Startup.Enabled = enabled
+		if v, ok := startupData["enabled"].(bool); ok {
+			settings.Startup.Enabled = v
 		}
-		// New key: alerts
 		if al, ok := startupData["alerts"].([]any); ok {
 			settings.Startup.Alerts = make([]string, 0, len(al))
 			for _, a := range al {
@@ -733,34 +1163,96 @@ func editSettings(stateManager *StateManager) {
 					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
 				}
 			}
-		} else if alerts, ok := startupData["alerts"].([]any); ok { // legacy
-			settings.Startup.Alerts = make([]string, 0, len(alerts))
-			for _, alert := range alerts {
-				if alertStr, ok := alert.(string); ok {
-					settings.Startup.Alerts = append(settings.Startup.Alerts, alertStr)
+		} else if al, ok := startupData["notifiers"].([]any); ok {
+			settings.Startup.Alerts = make([]string, 0, len(al))
+			for _, a := range al {
+				if s, ok := a.(string); ok {
+					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
+				}
+			}
+		} else if al, ok := startupData["alert_strategies"].([]any); ok {
+			settings.Startup.Alerts = make([]string, 0, len(al))
+			for _, a := range al {
+				if s, ok := a.(string); ok {
+					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
 				}
 			}
 		}
-		if checkAllTargets, ok := startupData["check_all_targets"].(bool); ok {
-			settings.Startup.CheckAllTargets = checkAllTargets
+		if v, ok := startupData["check_all_targets"].(bool); ok {
+			settings.Startup.CheckAllTargets = v
 		}
 	}
-
 	// Handle legacy startup_message setting for backward compatibility
-	if startupMessage, ok := settingsData["startup_message"].(bool); ok {
-		settings.Startup.Enabled = startupMessage
-		if startupMessage && len(settings.Startup.Alerts) == 0 {
+	if v, ok := settingsData["startup_message"].(bool); ok {
+		settings.Startup.Enabled = v
+		if v && len(settings.Startup.Alerts) == 0 {
 			settings.Startup.Alerts = []string{"console"}
 		}
 	}
-
+	// Parse shutdown configuration
+	if shutdownData, ok := settingsData["shutdown"].(map[string]any); ok {
+		if v, ok := shutdownData["enabled"].(bool); ok {
+			settings.Shutdown.Enabled = v
+		}
+		if alerts, ok := shutdownData["alerts"].([]any); ok {
+			settings.Shutdown.Alerts = make([]string, 0, len(alerts))
+			for _, alert := range alerts {
+				if alertStr, ok := alert.(string); ok {
+					settings.Shutdown.Alerts = append(settings.Shutdown.Alerts, alertStr)
+				}
+			}
+		}
+		if v, ok := shutdownData["status_report"].(bool); ok {
+			settings.Shutdown.StatusReport = v
+		}
+	}
+	// Parse alert backoff configuration
+	if backoffData, ok := settingsData["alert_backoff"].(map[string]any); ok {
+		if v, ok := yamlInt(backoffData["initial_seconds"]); ok {
+			settings.AlertBackoff.InitialSeconds = v
+		}
+		if v, ok := yamlFloat(backoffData["multiplier"]); ok {
+			settings.AlertBackoff.Multiplier = v
+		}
+		if v, ok := yamlInt(backoffData["max_seconds"]); ok {
+			settings.AlertBackoff.MaxSeconds = v
+		}
+	}
+	// Parse flap detection configuration
+	if flapData, ok := settingsData["flap_detection"].(map[string]any); ok {
+		if v, ok := yamlInt(flapData["transitions"]); ok {
+			settings.FlapDetection.Transitions = v
+		}
+		if v, ok := yamlInt(flapData["window_seconds"]); ok {
+			settings.FlapDetection.WindowSeconds = v
+		}
+	}
+	// Parse escalation stages
+	if stages, ok := settingsData["escalation"].([]any); ok {
+		settings.Escalation = parseEscalationStages(stages)
+	}
+	// Parse API authentication
+	if authData, ok := settingsData["api_auth"].(map[string]any); ok {
+		if v, ok := authData["bearer_token"].(string); ok {
+			settings.APIAuth.BearerToken = v
+		}
+		if v, ok := authData["username"].(string); ok {
+			settings.APIAuth.Username = v
+		}
+		if v, ok := authData["password"].(string); ok {
+			settings.APIAuth.Password = v
+		}
+	}
 	// Parse status report configuration
 	if statusReportData, ok := settingsData["status_report"].(map[string]any); ok {
-		if enabled, ok := statusReportData["enabled"].(bool); ok {
-			settings.StatusReport.Enabled = enabled
+		if v, ok := statusReportData["enabled"].(bool); ok {
+			settings.StatusReport.Enabled = v
+		}
+		if v, ok := yamlInt(statusReportData["interval"]); ok {
+			settings.StatusReport.Interval = v
 		}
-		if interval, ok := statusReportData["interval"].(int); ok {
-			settings.StatusReport.Interval = interval
+		if v, ok := statusReportData["schedule"].(string); ok {
+			settings.StatusReport.Schedule = v
 		}
 		if alerts, ok := statusReportData["alerts"].([]any); ok {
 			settings.StatusReport.Alerts = make([]string, 0, len(alerts))
@@ -770,22 +1262,19 @@ func editSettings(stateManager *StateManager) {
 				}
 			}
 		}
+		if groups, ok := statusReportData["groups"].(map[string]any); ok {
+			settings.StatusReport.Groups = make(map[string][]string, len(groups))
+			for tag, alerts := range groups {
+				names, _ := alerts.([]any)
+				for _, alert := range names {
+					if alertStr, ok := alert.(string); ok {
+						settings.StatusReport.Groups[tag] = append(settings.StatusReport.Groups[tag], alertStr)
+					}
+				}
+			}
+		}
 	}
-
-	// Validate settings
-	if err := validateSettings(settings); err != nil {
-		fmt.Printf("%s Invalid settings: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
-		fmt.Printf("%s Please fix the validation errors and try again.\n", qc.Colorize("💡 Tip:", qc.ColorYellow))
-		return
-	}
-
-	// Update settings in state manager
-	if err := stateManager.UpdateSettings(settings); err != nil {
-		fmt.Printf("%s Failed to update settings: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
-		return
-	}
-
-	fmt.Printf("%s Settings updated successfully!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
+	return settings
 }
 
 // createTempSettingsFile creates a temporary file with the current settings for editing
@@ -802,21 +1291,67 @@ func createTempSettingsFile(stateManager *StateManager) (string, error) {
 
 	// Create settings YAML structure
 	settingsOnly := map[string]any{
-		"webhook_port":             settings.WebhookPort,
-		"webhook_path":             settings.WebhookPath,
-		"server_address":           settings.ServerAddress,
-		"check_interval":           settings.CheckInterval,
-		"default_threshold":        settings.DefaultThreshold,
-		"acknowledgements_enabled": settings.AcknowledgementsEnabled,
+		"webhook_port":                settings.WebhookPort,
+		"webhook_path":                settings.WebhookPath,
+		"listen_host":                 settings.ListenHost,
+		"server_address":              settings.ServerAddress,
+		"check_interval":              settings.CheckInterval,
+		"default_threshold":           settings.DefaultThreshold,
+		"acknowledgements_enabled":    settings.AcknowledgementsEnabled,
+		"require_ack_for_autoresolve": settings.RequireAckForAutoresolve,
+		"ack_ttl":                     settings.AckTTL,
+		"initial_grace_seconds":       settings.InitialGraceSeconds,
+		"recovery_threshold":          settings.RecoveryThreshold,
+		"recovery_cooldown_seconds":   settings.RecoveryCooldownSeconds,
+		"notifier_failure_threshold":  settings.NotifierFailureThreshold,
+		"ca_bundle_file":              settings.CABundleFile,
+		"shutdown_timeout_seconds":    settings.ShutdownTimeoutSeconds,
+		"history_retention_hours":     settings.HistoryRetentionHours,
+		"check_history_size":          settings.CheckHistorySize,
+		"max_concurrent_checks":       settings.MaxConcurrentChecks,
+		"trigger_cooldown_seconds":    settings.TriggerCooldownSeconds,
+		"default_headers":             settings.DefaultHeaders,
+		"max_body_store_kb":           settings.MaxBodyStoreKB,
+		"capture_all_bodies":          settings.CaptureAllBodies,
+		"proxy_url":                   settings.ProxyURL,
+		"no_proxy":                    settings.NoProxy,
+		"duplicate_targets":           settings.DuplicateTargets,
+		"otlp_enabled":                settings.OTLPEnabled,
+		"otlp_endpoint":               settings.OTLPEndpoint,
+		"debug":                       settings.Debug,
+		"log_format":                  settings.LogFormat,
+		"timezone":                    settings.Timezone,
 		"startup": map[string]any{
 			"enabled":           settings.Startup.Enabled,
 			"alerts":            settings.Startup.Alerts,
 			"check_all_targets": settings.Startup.CheckAllTargets,
 		},
+		"shutdown": map[string]any{
+			"enabled":       settings.Shutdown.Enabled,
+			"alerts":        settings.Shutdown.Alerts,
+			"status_report": settings.Shutdown.StatusReport,
+		},
 		"status_report": map[string]any{
 			"enabled":  settings.StatusReport.Enabled,
 			"interval": settings.StatusReport.Interval,
+			"schedule": settings.StatusReport.Schedule,
 			"alerts":   settings.StatusReport.Alerts,
+			"groups":   settings.StatusReport.Groups,
+		},
+		"alert_backoff": map[string]any{
+			"initial_seconds": settings.AlertBackoff.InitialSeconds,
+			"multiplier":      settings.AlertBackoff.Multiplier,
+			"max_seconds":     settings.AlertBackoff.MaxSeconds,
+		},
+		"flap_detection": map[string]any{
+			"transitions":    settings.FlapDetection.Transitions,
+			"window_seconds": settings.FlapDetection.WindowSeconds,
+		},
+		"escalation": settings.Escalation,
+		"api_auth": map[string]any{
+			"bearer_token": settings.APIAuth.BearerToken,
+			"username":     settings.APIAuth.Username,
+			"password":     settings.APIAuth.Password,
 		},
 	}
 
@@ -846,18 +1381,62 @@ func addSettingsComments(data []byte) []byte {
 		{0, "", ""},
 		{0, "webhook_port: Port for webhook server", "(default: 8080)"},
 		{0, "webhook_path: Path for webhook endpoint", "(default: /webhook)"},
+		{0, "listen_host: Interface the server binds", "(default: all interfaces, e.g., 127.0.0.1)"},
 		{0, "server_address: Public server URL for alert links", "(e.g., https://monitor.example.com:8080)"},
 		{0, "check_interval: How often to check targets in seconds", "(default: 5s)"},
 		{0, "default_threshold: Default down threshold in seconds", "(default: 30s)"},
 		{0, "acknowledgements_enabled: Enable alert acknowledgements", "(default: false)"},
+		{0, "require_ack_for_autoresolve: Downgrade all-clears for unacknowledged incidents", "(default: false)"},
+		{0, "ack_ttl: Seconds an acknowledgement holds before alerts resume", "(default: 0, never expires)"},
+		{0, "shutdown_timeout_seconds: Graceful shutdown budget in seconds", "(default: 10)"},
+		{0, "ca_bundle_file: PEM CA bundle trusted for HTTPS checks", "(default: system roots only)"},
+		{0, "initial_grace_seconds: Extra wait before alerting on never-healthy new targets", "(default: 0)"},
+		{0, "recovery_threshold: Consecutive passing checks before a down target recovers", "(default: 1)"},
+		{0, "recovery_cooldown_seconds: Hold DOWN alerts this long after an ALL CLEAR", "(default: 0)"},
+		{0, "notifier_failure_threshold: Failed sends in a row before a notifier falls back", "(default: 3)"},
+		{0, "history_retention_hours: Drop check history older than this", "(default: 0, count cap only)"},
+		{0, "check_history_size: Checks kept in each target's history", "(default: 1000)"},
+		{0, "max_concurrent_checks: Checks run at once across all targets; the rest queue", "(default: 0, unlimited)"},
+		{0, "trigger_cooldown_seconds: Minimum wait between manual triggers of a target or status report", "(default: 0, no limit)"},
+		{0, "default_headers: Headers sent with every HTTP check; target headers win", "(default: User-Agent: quick_watch/<version>)"},
+		{0, "max_body_store_kb: KB of response body kept per check; targets may override", "(default: 10)"},
+		{0, "capture_all_bodies: Keep HTML and plain text bodies in history, not only JSON", "(default: false)"},
+		{0, "proxy_url: Proxy for checks, alert webhooks and OTLP (http://, https:// or socks5://)", "(default: HTTP_PROXY/HTTPS_PROXY)"},
+		{0, "no_proxy: [\".internal.example.com\", \"10.0.0.0/8\"] Hosts reached directly", "(default: NO_PROXY)"},
+		{0, "duplicate_targets: error, overwrite or merge when an added target's URL or name is taken", "(default: error)"},
+		{0, "otlp_enabled: Export spans and metrics to an OTLP/HTTP collector", "(default: false)"},
+		{0, "otlp_endpoint: OTLP/HTTP collector base URL", "(e.g., http://localhost:4318)"},
+		{0, "debug: Log engine diagnostics such as check retries", "(default: false)"},
+		{0, "log_format: text or json (one structured object per log line)", "(default: text)"},
+		{0, "timezone: zone for displayed timestamps, e.g. UTC or Europe/Berlin", "(default: local)"},
 		{0, "startup:", ""},
 		{2, "enabled: true/false", "(default: true)"},
 		{2, "alerts: [\"console\", \"slack-alerts\"]", "(default: [\"console\"])"},
 		{2, "check_all_targets: true/false", "(default: false)"},
+		{0, "shutdown:", ""},
+		{2, "enabled: true/false", "(default: false)"},
+		{2, "alerts: [\"console\", \"slack-alerts\"]", "(default: [])"},
+		{2, "status_report: true/false", "(send a final status report, default: false)"},
 		{0, "status_report:", ""},
 		{2, "enabled: true/false", "(default: false)"},
 		{2, "interval: 60", "(minutes, default: 60)"},
+		{2, "schedule: \"0 9 * * *\"", "(cron, local time; overrides interval)"},
 		{2, "alerts: [\"console\", \"slack-alerts\"]", "(default: [])"},
+		{2, "groups: {payments: [\"payments-slack\"]}", "(tag -> alerts for a report on only those targets)"},
+		{0, "alert_backoff: Re-alert schedule while a target stays down", ""},
+		{2, "initial_seconds: 60", "(wait after the first alert, default: 60)"},
+		{2, "multiplier: 5", "(growth per re-alert, default: 5)"},
+		{2, "max_seconds: 900", "(longest wait, default: 900)"},
+		{0, "flap_detection: Hold up/down alerts for targets that keep changing state", ""},
+		{2, "transitions: 4", "(changes within the window that mean flapping, default: 0 = off)"},
+		{2, "window_seconds: 600", "(default: 600)"},
+		{0, "escalation: Page more alerts while a down target stays unacknowledged", ""},
+		{2, "- after_minutes: 15", "(minutes since the first DOWN alert)"},
+		{2, "  alerts: [\"pagerduty\"]", "(targets may set their own escalation)"},
+		{0, "api_auth: Credentials for the dashboard, /targets and /api/* (/health stays open)", ""},
+		{2, "bearer_token: ${QW_API_TOKEN}", "(Authorization: Bearer <token>)"},
+		{2, "username: admin", "(HTTP basic auth, used by browsers)"},
+		{2, "password: ${QW_API_PASSWORD}", ""},
 		{0, "", ""},
 		{0, "", ""},
 	})
@@ -872,6 +1451,9 @@ func validateSettingsYAML(data []byte) error {
 	return yaml.Unmarshal(data, &temp)
 }
 
+// listenHostnamePattern matches a bare DNS hostname such as "localhost"
+var listenHostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?$`)
+
 // validateSettings validates settings configuration
 func validateSettings(settings ServerSettings) error {
 	if settings.WebhookPort < 1 || settings.WebhookPort > 65535 {
@@ -880,17 +1462,119 @@ func validateSettings(settings ServerSettings) error {
 	if settings.WebhookPath == "" {
 		return fmt.Errorf("webhook_path cannot be empty")
 	}
+	if settings.ListenHost != "" && net.ParseIP(settings.ListenHost) == nil && !listenHostnamePattern.MatchString(settings.ListenHost) {
+		return fmt.Errorf("listen_host must be an IP address or hostname without a port, got %s", settings.ListenHost)
+	}
 	if settings.CheckInterval < 1 {
 		return fmt.Errorf("check_interval must be at least 1 second, got %d", settings.CheckInterval)
 	}
 	if settings.DefaultThreshold < 1 {
 		return fmt.Errorf("default_threshold must be at least 1 second, got %d", settings.DefaultThreshold)
 	}
+	if settings.ShutdownTimeoutSeconds < 0 {
+		return fmt.Errorf("shutdown_timeout_seconds cannot be negative, got %d", settings.ShutdownTimeoutSeconds)
+	}
+	if settings.CABundleFile != "" {
+		if _, err := loadCABundle(settings.CABundleFile); err != nil {
+			return fmt.Errorf("ca_bundle_file: %v", err)
+		}
+	}
+	if settings.StatusReport.Schedule != "" {
+		schedule, err := ParseCronSchedule(settings.StatusReport.Schedule)
+		if err != nil {
+			return fmt.Errorf("status_report.schedule: %v", err)
+		}
+		if schedule.Next(time.Now()).IsZero() {
+			return fmt.Errorf("status_report.schedule %q never fires", settings.StatusReport.Schedule)
+		}
+	}
+	for tag, alerts := range settings.StatusReport.Groups {
+		if strings.TrimSpace(tag) == "" || len(alerts) == 0 {
+			return fmt.Errorf("status_report.groups: each entry needs a tag and at least one alert, got %q: %v", tag, alerts)
+		}
+	}
+	if settings.AckTTL < 0 {
+		return fmt.Errorf("ack_ttl cannot be negative, got %d", settings.AckTTL)
+	}
+	if settings.InitialGraceSeconds < 0 {
+		return fmt.Errorf("initial_grace_seconds cannot be negative, got %d", settings.InitialGraceSeconds)
+	}
+	if settings.RecoveryThreshold < 0 {
+		return fmt.Errorf("recovery_threshold cannot be negative, got %d", settings.RecoveryThreshold)
+	}
+	if settings.RecoveryCooldownSeconds < 0 {
+		return fmt.Errorf("recovery_cooldown_seconds cannot be negative, got %d", settings.RecoveryCooldownSeconds)
+	}
+	if settings.NotifierFailureThreshold < 0 {
+		return fmt.Errorf("notifier_failure_threshold cannot be negative, got %d", settings.NotifierFailureThreshold)
+	}
+	if settings.HistoryRetentionHours < 0 {
+		return fmt.Errorf("history_retention_hours cannot be negative, got %d", settings.HistoryRetentionHours)
+	}
+	if settings.CheckHistorySize < 0 || settings.CheckHistorySize > maxCheckHistorySize {
+		return fmt.Errorf("check_history_size must be between 0 and %d, got %d", maxCheckHistorySize, settings.CheckHistorySize)
+	}
+	if settings.MaxConcurrentChecks < 0 {
+		return fmt.Errorf("max_concurrent_checks cannot be negative, got %d", settings.MaxConcurrentChecks)
+	}
+	if settings.TriggerCooldownSeconds < 0 {
+		return fmt.Errorf("trigger_cooldown_seconds cannot be negative, got %d", settings.TriggerCooldownSeconds)
+	}
+	for name := range settings.DefaultHeaders {
+		if name == "" || strings.ContainsAny(name, " \t:") {
+			return fmt.Errorf("default_headers: invalid header name %q", name)
+		}
+	}
+	if settings.ProxyURL != "" {
+		if _, err := parseProxyURL(settings.ProxyURL); err != nil {
+			return err
+		}
+	}
+	if settings.DuplicateTargets != "" && !slices.Contains(duplicateTargetPolicies, settings.DuplicateTargets) {
+		return fmt.Errorf("duplicate_targets must be one of %s, got %q", strings.Join(duplicateTargetPolicies, ", "), settings.DuplicateTargets)
+	}
+	if settings.MaxBodyStoreKB < 0 {
+		return fmt.Errorf("max_body_store_kb cannot be negative, got %d", settings.MaxBodyStoreKB)
+	}
+	if settings.OTLPEnabled && settings.OTLPEndpoint == "" {
+		return fmt.Errorf("otlp_endpoint is required when otlp_enabled is true")
+	}
+	if settings.LogFormat != "" && settings.LogFormat != logFormatText && settings.LogFormat != logFormatJSON {
+		return fmt.Errorf("log_format must be %q or %q, got %q", logFormatText, logFormatJSON, settings.LogFormat)
+	}
+	if _, err := loadTimezone(settings.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %v", settings.Timezone, err)
+	}
+	if settings.OTLPEndpoint != "" && !strings.HasPrefix(settings.OTLPEndpoint, "http://") && !strings.HasPrefix(settings.OTLPEndpoint, "https://") {
+		return fmt.Errorf("otlp_endpoint must start with http:// or https://, got %s", settings.OTLPEndpoint)
+	}
+	if settings.AlertBackoff.InitialSeconds < 0 || settings.AlertBackoff.MaxSeconds < 0 {
+		return fmt.Errorf("alert_backoff initial_seconds and max_seconds cannot be negative")
+	}
+	if settings.AlertBackoff.Multiplier != 0 && settings.AlertBackoff.Multiplier < 1 {
+		return fmt.Errorf("alert_backoff multiplier must be at least 1, got %g", settings.AlertBackoff.Multiplier)
+	}
+	if settings.AlertBackoff.MaxSeconds > 0 && settings.AlertBackoff.MaxSeconds < settings.AlertBackoff.InitialSeconds {
+		return fmt.Errorf("alert_backoff max_seconds (%d) cannot be less than initial_seconds (%d)", settings.AlertBackoff.MaxSeconds, settings.AlertBackoff.InitialSeconds)
+	}
+	if settings.FlapDetection.Transitions < 0 || settings.FlapDetection.WindowSeconds < 0 {
+		return fmt.Errorf("flap_detection transitions and window_seconds cannot be negative")
+	}
+	if err := validateEscalation(settings.Escalation); err != nil {
+		return err
+	}
+
+	if (settings.APIAuth.Username == "") != (settings.APIAuth.Password == "") {
+		return fmt.Errorf("api_auth username and password must be set together")
+	}
 
 	// Validate startup configuration
 	if settings.Startup.Enabled && len(settings.Startup.Alerts) == 0 {
 		return fmt.Errorf("startup is enabled but no alerts specified")
 	}
+	if settings.Shutdown.Enabled && len(settings.Shutdown.Alerts) == 0 {
+		return fmt.Errorf("shutdown is enabled but no alerts specified")
+	}
 
 	return nil
 }
@@ -1063,10 +1747,19 @@ func addAlertsComments(data []byte) []byte {
 		{0, "Edit alerts below. Each key is the alert name.", ""},
 		{0, "For console, only 'type: console' is required.", ""},
 		{0, "For slack, 'type: slack' and 'settings.webhook_url' are required.", ""},
+		{0, "  Or use a Slack app: settings.bot_token_env (env var with xoxb- token) + settings.channel.", ""},
 		{0, "For email, 'type: email' and SMTP settings are required.", ""},
 		{0, "  Use settings.password_env to reference an environment variable for SMTP password.", ""},
 		{0, "For file, 'type: file' and 'settings.file_path' are required.", ""},
 		{0, "  Writes OTEL-like JSON logs to the specified file.", ""},
+		{0, "For webhook, 'type: webhook' and 'settings.webhook_url' are required.", ""},
+		{0, "  Optional settings.auth uses bearer_token_env, or username + password_env (not both).", ""},
+		{0, "  Optional settings.body_template (Go template) shapes the body; settings.headers adds headers.", ""},
+		{0, "Optional settings.max_message_length truncates long alerts (0 = unlimited).", ""},
+		{0, "  Defaults: slack 3000, webhook 4000, email 10000.", ""},
+		{0, "Optional settings.active_hours or settings.quiet_hours (\"22:00-07:00\") limit when alerts send.", ""},
+		{0, "  settings.timezone sets their zone (default local); settings.always_send_down lets DOWN through.", ""},
+		{0, "Optional settings.fallback_alert names the alert used while this one keeps failing (default: console).", ""},
 		{0, "", ""},
 		{0, "Full examples:", ""},
 		{0, "my-console-alert:", ""},
@@ -1074,7 +1767,7 @@ func addAlertsComments(data []byte) []byte {
 		{2, "enabled: true", ""},
 		{2, "description: \"Console output\"", ""},
 		{2, "settings:", ""},
-		{4, "style: stylized", ""},
+		{4, "style: stylized", "(or plain, or compact for one line per event)"},
 		{4, "color: true", ""},
 		{0, "", ""},
 		{0, "my-slack-alert:", ""},
@@ -1097,7 +1790,11 @@ func addAlertsComments(data []byte) []byte {
 		{4, "smtp_port: 587", ""},
 		{4, "username: alerts@example.com", ""},
 		{4, "password_env: SMTP_TOKEN", ""},
-		{4, "to: admin@example.com", ""},
+		{4, "to: admin@example.com  # Comma-separated string or a YAML list", ""},
+		{4, "cc: oncall@example.com  # Optional, also accepts a list", ""},
+		{4, "bcc: audit@example.com  # Optional, hidden from message headers", ""},
+		{4, "tls_mode: starttls  # none, starttls (port 587) or tls (port 465); default upgrades when offered", ""},
+		{4, "skip_verify: false  # Skip certificate verification (self-signed relays only)", ""},
 		{4, "debug: false  # Enable verbose SMTP logging", ""},
 		{0, "", ""},
 		{0, "my-file-alert:", ""},
@@ -1109,6 +1806,22 @@ func addAlertsComments(data []byte) []byte {
 		{4, "debug: false  # Enable verbose file logging", ""},
 		{4, "max_size_before_compress: 100  # Rotate and compress after 100MB (checked hourly)", ""},
 		{0, "", ""},
+		{0, "my-webhook-alert:", ""},
+		{2, "type: webhook", ""},
+		{2, "enabled: true", ""},
+		{2, "description: \"Internal alert collector\"", ""},
+		{2, "settings:", ""},
+		{4, "webhook_url: https://collector.internal/alerts", ""},
+		{4, "auth:", ""},
+		{6, "bearer_token_env: COLLECTOR_TOKEN", ""},
+		{4, "headers:", ""},
+		{6, "X-Source: quick-watch", ""},
+		{4, "body_template: '{\"text\": \"{{.Target}} is {{.Status}}\", \"alerts\": {{.AlertCount}}}'", ""},
+		{4, "client_cert_file: /etc/quick_watch/client.crt  # Optional mutual TLS, with client_key_file", ""},
+		{4, "client_key_file: /etc/quick_watch/client.key", ""},
+		{4, "ca_bundle_file: /etc/quick_watch/internal-ca.pem  # Optional CA trusted beyond system roots", ""},
+		{4, "skip_verify: false  # Skip certificate verification (self-signed test collectors only)", ""},
+		{0, "", ""},
 		{0, "", ""},
 	})
 	commentedLines := append(rendered, lines...)
@@ -1154,7 +1867,7 @@ func applyTargetsYAML(stateManager *StateManager, modifiedData []byte) {
 			if !fields.Headers && len(target.Headers) == 0 && existing.Headers != nil {
 				target.Headers = existing.Headers
 			}
-			if !fields.Threshold && target.Threshold == 0 {
+			if !fields.Threshold && target.Threshold == 0 && target.DownAfterFailures == 0 {
 				target.Threshold = existing.Threshold
 			}
 			if !fields.StatusCodes && len(target.StatusCodes) == 0 && len(existing.StatusCodes) > 0 {
@@ -1180,6 +1893,7 @@ func applyTargetsYAML(stateManager *StateManager, modifiedData []byte) {
 			if target.Name == "" {
 				target.Name = existing.Name
 			}
+			preserveTargetOptions(&target, existing, fields)
 		}
 		if err := stateManager.AddTarget(target); err != nil {
 			fmt.Printf("%s Failed to save target %s: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), url, err)
@@ -1215,10 +1929,6 @@ func applyTargetsYAML(stateManager *StateManager, modifiedData []byte) {
 		if strings.TrimSpace(displayMethod) == "" {
 			displayMethod = "GET"
 		}
-		displayThreshold := target.Threshold
-		if displayThreshold == 0 {
-			displayThreshold = 30
-		}
 		displayCheck := target.CheckStrategy
 		if strings.TrimSpace(displayCheck) == "" {
 			displayCheck = "http"
@@ -1231,7 +1941,7 @@ func applyTargetsYAML(stateManager *StateManager, modifiedData []byte) {
 				displayAlerts = "console"
 			}
 		}
-		fmt.Printf("     Method: %s, Threshold: %ds, Check: %s, Alert: %s\n", displayMethod, displayThreshold, displayCheck, displayAlerts)
+		fmt.Printf("     Method: %s, Threshold: %s, Check: %s, Alert: %s\n", displayMethod, describeThreshold(target), displayCheck, displayAlerts)
 		i++
 	}
 	fmt.Printf("\n%s Configuration saved successfully!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
@@ -1249,85 +1959,7 @@ func applySettingsYAML(stateManager *StateManager, modifiedData []byte) {
 		fmt.Printf("%s Failed to parse YAML: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
 		return
 	}
-	settings := ServerSettings{
-		WebhookPort:             8080,
-		WebhookPath:             "/webhook",
-		CheckInterval:           5,
-		DefaultThreshold:        30,
-		Startup:                 StartupConfig{Enabled: true, Alerts: []string{"console"}},
-		AcknowledgementsEnabled: false,
-	}
-	if v, ok := settingsData["webhook_port"].(int); ok {
-		settings.WebhookPort = v
-	}
-	if v, ok := settingsData["webhook_path"].(string); ok {
-		settings.WebhookPath = v
-	}
-	if v, ok := settingsData["server_address"].(string); ok {
-		settings.ServerAddress = v
-	}
-	if v, ok := settingsData["check_interval"].(int); ok {
-		settings.CheckInterval = v
-	}
-	if v, ok := settingsData["default_threshold"].(int); ok {
-		settings.DefaultThreshold = v
-	}
-	if v, ok := settingsData["acknowledgements_enabled"].(bool); ok {
-		settings.AcknowledgementsEnabled = v
-	}
-	if startupData, ok := settingsData["startup"].(map[string]any); ok {
-		if v, ok := startupData["enabled"].(bool); ok {
-			settings.Startup.Enabled = v
-		}
-		if al, ok := startupData["alerts"].([]any); ok {
-			settings.Startup.Alerts = make([]string, 0, len(al))
-			for _, a := range al {
-				if s, ok := a.(string); ok {
-					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
-				}
-			}
-		} else if al, ok := startupData["notifiers"].([]any); ok {
-			settings.Startup.Alerts = make([]string, 0, len(al))
-			for _, a := range al {
-				if s, ok := a.(string); ok {
-					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
-				}
-			}
-		} else if al, ok := startupData["alert_strategies"].([]any); ok {
-			settings.Startup.Alerts = make([]string, 0, len(al))
-			for _, a := range al {
-				if s, ok := a.(string); ok {
-					settings.Startup.Alerts = append(settings.Startup.Alerts, s)
-				}
-			}
-		}
-		if v, ok := startupData["check_all_targets"].(bool); ok {
-			settings.Startup.CheckAllTargets = v
-		}
-	}
-	if v, ok := settingsData["startup_message"].(bool); ok {
-		settings.Startup.Enabled = v
-		if v && len(settings.Startup.Alerts) == 0 {
-			settings.Startup.Alerts = []string{"console"}
-		}
-	}
-	// Parse status report configuration
-	if statusReportData, ok := settingsData["status_report"].(map[string]any); ok {
-		if v, ok := statusReportData["enabled"].(bool); ok {
-			settings.StatusReport.Enabled = v
-		}
-		if v, ok := statusReportData["interval"].(int); ok {
-			settings.StatusReport.Interval = v
-		}
-		if alerts, ok := statusReportData["alerts"].([]any); ok {
-			settings.StatusReport.Alerts = make([]string, 0, len(alerts))
-			for _, alert := range alerts {
-				if alertStr, ok := alert.(string); ok {
-					settings.StatusReport.Alerts = append(settings.StatusReport.Alerts, alertStr)
-				}
-			}
-		}
-	}
+	settings := parseSettingsData(settingsData)
 	if err := validateSettings(settings); err != nil {
 		fmt.Printf("%s Invalid settings: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
 		fmt.Printf("%s Please fix the validation errors and try again.\n", qc.Colorize("💡 Tip:", qc.ColorYellow))
@@ -1371,6 +2003,9 @@ func applySettingsYAML(stateManager *StateManager, modifiedData []byte) {
 	if settings.Startup.CheckAllTargets {
 		fmt.Printf("     Startup Check All Targets: true\n")
 	}
+	if settings.Shutdown.Enabled {
+		fmt.Printf("  %s Shutdown: enabled (%s)\n", qc.Colorize("-", qc.ColorYellow), strings.Join(settings.Shutdown.Alerts, ", "))
+	}
 
 	fmt.Printf("\n%s Settings updated successfully!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
 }
@@ -1470,8 +2105,13 @@ func applyAlertsYAML(stateManager *StateManager, modifiedData []byte) {
 	fmt.Printf("\n%s Alerts updated successfully!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
 }
 
-// parseTargetsFromYAML parses targets from any of the supported editor formats
+// parseTargetsFromYAML parses targets from any of the supported editor formats, after
+// merging include: files and the defaults: block
 func parseTargetsFromYAML(data []byte) (map[string]Target, map[string]*TargetFields, error) {
+	data, err := resolveIncludesAndDefaults(data, ".")
+	if err != nil {
+		return nil, nil, err
+	}
 	var targetsData map[string]any
 	if err := yaml.Unmarshal(data, &targetsData); err != nil {
 		return nil, nil, err
@@ -1482,24 +2122,30 @@ func parseTargetsFromYAML(data []byte) (map[string]Target, map[string]*TargetFie
 
 	// Prefer wrapped under "targets"
 	if targetsInterface, ok := targetsData["targets"]; ok {
-		parseTargetsInterface(targetsInterface, targetsMap, targetFieldsMap)
+		if err := parseTargetsInterface(targetsInterface, targetsMap, targetFieldsMap); err != nil {
+			return nil, nil, err
+		}
 	}
 	// Legacy "targets"
 	if len(targetsMap) == 0 {
 		if targetsInterface, ok := targetsData["targets"]; ok {
-			parseTargetsInterface(targetsInterface, targetsMap, targetFieldsMap)
+			if err := parseTargetsInterface(targetsInterface, targetsMap, targetFieldsMap); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 	// Simplified top-level
 	if len(targetsMap) == 0 {
-		parseTargetsInterface(targetsData, targetsMap, targetFieldsMap)
+		if err := parseTargetsInterface(targetsData, targetsMap, targetFieldsMap); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return targetsMap, targetFieldsMap, nil
 }
 
 // parseTargetsInterface fills maps from either map[string]any or []any structures
-func parseTargetsInterface(src any, out map[string]Target, fields map[string]*TargetFields) {
+func parseTargetsInterface(src any, out map[string]Target, fields map[string]*TargetFields) error {
 	switch v := src.(type) {
 	case map[string]any:
 		for key, targetInterface := range v {
@@ -1518,7 +2164,7 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 					target.Method = method
 					f.Method = true
 				}
-				if threshold, ok := targetMap["threshold"].(int); ok {
+				if threshold, ok := yamlInt(targetMap["threshold"]); ok {
 					target.Threshold = threshold
 					f.Threshold = true
 				}
@@ -1544,10 +2190,10 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 					if enabled, ok := sizeAlerts["enabled"].(bool); ok {
 						target.SizeAlerts.Enabled = enabled
 					}
-					if historySize, ok := sizeAlerts["history_size"].(int); ok {
+					if historySize, ok := yamlInt(sizeAlerts["history_size"]); ok {
 						target.SizeAlerts.HistorySize = historySize
 					}
-					if th, ok := sizeAlerts["threshold"].(float64); ok {
+					if th, ok := yamlFloat(sizeAlerts["threshold"]); ok {
 						target.SizeAlerts.Threshold = th
 					}
 				}
@@ -1555,20 +2201,20 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 					target.CheckStrategy = checkStrategy
 					f.CheckStrategy = true
 				}
-				if duration, ok := targetMap["duration"].(int); ok {
+				if duration, ok := yamlInt(targetMap["duration"]); ok {
 					target.Duration = duration
 				}
 				// Ports: parse array of integers for TCP checks
 				if ports, ok := targetMap["ports"].([]any); ok {
 					f.Ports = true
 					for _, port := range ports {
-						if portInt, ok := port.(int); ok {
+						if portInt, ok := yamlInt(port); ok {
 							target.Ports = append(target.Ports, portInt)
 						}
 					}
 				}
 				// Visual threshold: for page-comparison strategy
-				if visualThreshold, ok := targetMap["visual_threshold"].(float64); ok {
+				if visualThreshold, ok := yamlFloat(targetMap["visual_threshold"]); ok {
 					target.VisualThreshold = visualThreshold
 				}
 				// Screenshot path: for page-comparison strategy
@@ -1594,6 +2240,9 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 						}
 					}
 				}
+				if err := parseTargetOptions(targetMap, &target, f); err != nil {
+					return fmt.Errorf("target %s: %w", target.Name, err)
+				}
 				if target.URL != "" {
 					out[target.URL] = target
 					fields[target.URL] = f
@@ -1615,7 +2264,7 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 					target.Method = method
 					f.Method = true
 				}
-				if threshold, ok := targetMap["threshold"].(int); ok {
+				if threshold, ok := yamlInt(targetMap["threshold"]); ok {
 					target.Threshold = threshold
 					f.Threshold = true
 				}
@@ -1631,13 +2280,13 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 				if ports, ok := targetMap["ports"].([]any); ok {
 					f.Ports = true
 					for _, port := range ports {
-						if portInt, ok := port.(int); ok {
+						if portInt, ok := yamlInt(port); ok {
 							target.Ports = append(target.Ports, portInt)
 						}
 					}
 				}
 				// Visual threshold: for page-comparison strategy
-				if visualThreshold, ok := targetMap["visual_threshold"].(float64); ok {
+				if visualThreshold, ok := yamlFloat(targetMap["visual_threshold"]); ok {
 					target.VisualThreshold = visualThreshold
 				}
 				// Screenshot path: for page-comparison strategy
@@ -1663,6 +2312,9 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 						}
 					}
 				}
+				if err := parseTargetOptions(targetMap, &target, f); err != nil {
+					return fmt.Errorf("target %s: %w", target.Name, err)
+				}
 				if target.URL != "" {
 					out[target.URL] = target
 					fields[target.URL] = f
@@ -1670,6 +2322,461 @@ func parseTargetsInterface(src any, out map[string]Target, fields map[string]*Ta
 			}
 		}
 	}
+	return nil
+}
+
+// parseEscalationStages reads a list of {after_minutes, alerts} escalation stages
+func parseEscalationStages(items []any) []EscalationStage {
+	stages := make([]EscalationStage, 0, len(items))
+	for _, item := range items {
+		stageMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		var stage EscalationStage
+		if v, ok := yamlInt(stageMap["after_minutes"]); ok {
+			stage.AfterMinutes = v
+		}
+		switch names := stageMap["alerts"].(type) {
+		case string:
+			stage.Alerts = []string{names}
+		case []any:
+			for _, name := range names {
+				if str, ok := name.(string); ok {
+					stage.Alerts = append(stage.Alerts, str)
+				}
+			}
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// validateEscalation checks that escalation stages page at least one alert each and come
+// in order of increasing after_minutes
+func validateEscalation(stages []EscalationStage) error {
+	previous := 0
+	for i, stage := range stages {
+		if stage.AfterMinutes <= 0 {
+			return fmt.Errorf("escalation stage %d: after_minutes must be a positive number of minutes, got %d", i+1, stage.AfterMinutes)
+		}
+		if i > 0 && stage.AfterMinutes <= previous {
+			return fmt.Errorf("escalation stage %d: after_minutes (%d) must be greater than the previous stage's (%d)", i+1, stage.AfterMinutes, previous)
+		}
+		if len(stage.Alerts) == 0 || slices.Contains(stage.Alerts, "") {
+			return fmt.Errorf("escalation stage %d must list at least one alert name", i+1)
+		}
+		previous = stage.AfterMinutes
+	}
+	return nil
+}
+
+// yamlInt coerces a YAML scalar to an int. YAML type inference can turn a number into
+// a float (30.0) or a string ("30"), so whole floats and numeric strings are accepted too.
+func yamlInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		if n == math.Trunc(n) {
+			return int(n), true
+		}
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil && f == math.Trunc(f) {
+			return int(f), true
+		}
+	}
+	return 0, false
+}
+
+// yamlFloat coerces a YAML scalar (int, float or numeric string) to a float64
+func yamlFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// parseTargetOptions reads optional per-target behaviour settings from an edited target entry,
+// rejecting urls entries that are not strings
+func parseTargetOptions(targetMap map[string]any, target *Target, f *TargetFields) error {
+	if v, ok := targetMap["insecure_skip_verify"].(bool); ok {
+		target.InsecureSkipVerify = v
+		f.InsecureSkipVerify = true
+	}
+	if v, ok := targetMap["paused"].(bool); ok {
+		target.Paused = v
+		f.Paused = true
+	}
+	if v, ok := targetMap["client_cert_file"].(string); ok {
+		target.ClientCertFile = v
+	}
+	if v, ok := targetMap["client_key_file"].(string); ok {
+		target.ClientKeyFile = v
+	}
+	if v, ok := targetMap["ca_bundle_file"].(string); ok {
+		target.CABundleFile = v
+	}
+	if v, ok := targetMap["grpc_service"].(string); ok {
+		target.GRPCService = v
+	}
+	if v, ok := targetMap["grpc_tls"].(bool); ok {
+		target.GRPCTLS = v
+		f.GRPCTLS = true
+	}
+	if v, ok := targetMap["grpc_discovery"].(string); ok {
+		target.GRPCDiscovery = v
+	}
+	if v, ok := yamlInt(targetMap["ping_count"]); ok {
+		target.PingCount = v
+	}
+	if v, ok := yamlFloat(targetMap["max_packet_loss"]); ok {
+		target.MaxPacketLoss = v
+	}
+	if v, ok := yamlInt(targetMap["max_redirects"]); ok {
+		target.MaxRedirects = v
+	}
+	if v, ok := targetMap["follow_redirects"].(bool); ok {
+		target.FollowRedirects = &v
+	}
+	if v, ok := yamlInt(targetMap["timeout"]); ok {
+		target.Timeout = v
+	}
+	if v, ok := targetMap["body"].(string); ok {
+		target.Body = v
+	}
+	if v, ok := targetMap["body_match"].(string); ok {
+		target.BodyMatch = v
+	}
+	if v, ok := yamlInt(targetMap["retry_on_failure"]); ok {
+		target.RetryOnFailure = v
+	}
+	if phaseMap, ok := targetMap["phase_thresholds"].(map[string]any); ok {
+		target.PhaseThresholds = make(map[string]int, len(phaseMap))
+		for phase, limit := range phaseMap {
+			if ms, ok := yamlInt(limit); ok {
+				target.PhaseThresholds[phase] = ms
+			}
+		}
+	}
+	if v, ok := yamlInt(targetMap["max_body_read_kb"]); ok {
+		target.MaxBodyReadKB = v
+	}
+	if v, ok := yamlInt(targetMap["max_body_store_kb"]); ok {
+		target.MaxBodyStoreKB = v
+	}
+	if v, ok := yamlInt(targetMap["initial_grace_seconds"]); ok {
+		target.InitialGraceSeconds = v
+	}
+	if v, ok := yamlInt(targetMap["recovery_threshold"]); ok {
+		target.RecoveryThreshold = v
+	}
+	if v, ok := yamlInt(targetMap["recovery_cooldown_seconds"]); ok {
+		target.RecoveryCooldownSeconds = v
+	}
+	if v, ok := yamlInt(targetMap["check_history_size"]); ok {
+		target.CheckHistorySize = v
+	}
+	if contentAlerts, ok := targetMap["content_alerts"].(map[string]any); ok {
+		target.ContentAlerts = &ContentAlertConfig{}
+		if enabled, ok := contentAlerts["enabled"].(bool); ok {
+			target.ContentAlerts.Enabled = enabled
+		}
+		if historySize, ok := yamlInt(contentAlerts["history_size"]); ok {
+			target.ContentAlerts.HistorySize = historySize
+		}
+	}
+	if v, ok := yamlInt(targetMap["interval"]); ok {
+		target.Interval = v
+	}
+	if v, ok := yamlInt(targetMap["retries"]); ok {
+		target.Retries = v
+	}
+	if v, ok := yamlInt(targetMap["max_response_time"]); ok {
+		target.MaxResponseTime = v
+	}
+	if names, ok := targetMap["slow_alerts"].([]any); ok {
+		target.SlowAlerts = make([]string, 0, len(names))
+		for _, name := range names {
+			if str, ok := name.(string); ok {
+				target.SlowAlerts = append(target.SlowAlerts, str)
+			}
+		}
+	}
+	if routes, ok := targetMap["alert_routes"].(map[string]any); ok {
+		target.AlertRoutes = make(map[string][]string, len(routes))
+		for event, value := range routes {
+			switch names := value.(type) {
+			case string:
+				target.AlertRoutes[event] = []string{names}
+			case []any:
+				for _, name := range names {
+					if str, ok := name.(string); ok {
+						target.AlertRoutes[event] = append(target.AlertRoutes[event], str)
+					}
+				}
+			}
+		}
+	}
+	if v, ok := targetMap["require_ack_for_autoresolve"].(bool); ok {
+		target.RequireAckForAutoresolve = &v
+	}
+	if extractMap, ok := targetMap["extract"].(map[string]any); ok {
+		target.Extract = make(map[string]string, len(extractMap))
+		for name, path := range extractMap {
+			if str, ok := path.(string); ok {
+				target.Extract[name] = str
+			}
+		}
+	}
+	if assertions, ok := targetMap["json_assertions"].([]any); ok {
+		target.JSONAssertions = make([]JSONAssertion, 0, len(assertions))
+		for _, item := range assertions {
+			if assertion, ok := item.(map[string]any); ok {
+				path, _ := assertion["path"].(string)
+				target.JSONAssertions = append(target.JSONAssertions, JSONAssertion{Path: path, Equals: assertion["equals"]})
+			}
+		}
+	}
+	if stages, ok := targetMap["escalation"].([]any); ok {
+		target.Escalation = parseEscalationStages(stages)
+	}
+	switch v := targetMap["ip_version"].(type) {
+	case string:
+		target.IPVersion = v
+	case int:
+		target.IPVersion = strconv.Itoa(v)
+	}
+	if v, ok := yamlInt(targetMap["failure_count"]); ok {
+		target.DownAfterFailures = v
+	}
+	if v, ok := targetMap["slack_channel"].(string); ok {
+		target.SlackChannel = v
+	}
+	if v, ok := targetMap["slack_mention"].(string); ok {
+		target.SlackMention = v
+	}
+	if v, ok := targetMap["expected_content_type"].(string); ok {
+		target.ExpectedContentType = v
+	}
+	if callbackMap, ok := targetMap["callback"].(map[string]any); ok {
+		callback := &WebhookCallback{}
+		callback.URL, _ = callbackMap["url"].(string)
+		callback.Method, _ = callbackMap["method"].(string)
+		callback.BodyTemplate, _ = callbackMap["body_template"].(string)
+		if headers, ok := callbackMap["headers"].(map[string]any); ok {
+			callback.Headers = make(map[string]string, len(headers))
+			for name, value := range headers {
+				if str, ok := value.(string); ok {
+					callback.Headers[name] = str
+				}
+			}
+		}
+		target.Callback = callback
+	}
+	if urls, ok := targetMap["urls"].([]any); ok {
+		target.URLs = make([]string, 0, len(urls))
+		for _, url := range urls {
+			str, ok := url.(string)
+			if !ok {
+				return fmt.Errorf("urls: entry %v is not a URL string", url)
+			}
+			target.URLs = append(target.URLs, str)
+		}
+	}
+	if v, ok := targetMap["backend_policy"].(string); ok {
+		target.BackendPolicy = v
+	}
+	if v, ok := yamlInt(targetMap["backend_quorum"]); ok {
+		target.BackendQuorum = v
+	}
+	if v, ok := targetMap["alert_message_template"].(string); ok {
+		target.AlertMessageTemplate = v
+	}
+	if v, ok := targetMap["severity"].(string); ok {
+		target.Severity = v
+	}
+	if tags, ok := targetMap["tags"].([]any); ok {
+		target.Tags = make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if str, ok := tag.(string); ok {
+				target.Tags = append(target.Tags, str)
+			}
+		}
+	}
+	if deps, ok := targetMap["depends_on"].([]any); ok {
+		target.DependsOn = make([]string, 0, len(deps))
+		for _, dep := range deps {
+			if str, ok := dep.(string); ok {
+				target.DependsOn = append(target.DependsOn, str)
+			}
+		}
+	}
+	if cookieMap, ok := targetMap["cookies"].(map[string]any); ok {
+		target.Cookies = make(map[string]string, len(cookieMap))
+		for name, value := range cookieMap {
+			if str, ok := value.(string); ok {
+				target.Cookies[name] = str
+			}
+		}
+	}
+	return nil
+}
+
+// preserveTargetOptions carries optional per-target settings over from the stored target
+// when the edited entry does not specify them
+func preserveTargetOptions(target *Target, existing Target, fields *TargetFields) {
+	if !fields.InsecureSkipVerify {
+		target.InsecureSkipVerify = existing.InsecureSkipVerify
+	}
+	if !fields.Paused {
+		target.Paused = existing.Paused
+	}
+	if target.CABundleFile == "" {
+		target.CABundleFile = existing.CABundleFile
+	}
+	if target.ClientCertFile == "" && target.ClientKeyFile == "" {
+		target.ClientCertFile = existing.ClientCertFile
+		target.ClientKeyFile = existing.ClientKeyFile
+	}
+	if target.GRPCService == "" {
+		target.GRPCService = existing.GRPCService
+	}
+	if !fields.GRPCTLS {
+		target.GRPCTLS = existing.GRPCTLS
+	}
+	if target.GRPCDiscovery == "" {
+		target.GRPCDiscovery = existing.GRPCDiscovery
+	}
+	if target.PingCount == 0 {
+		target.PingCount = existing.PingCount
+	}
+	if target.MaxPacketLoss == 0 {
+		target.MaxPacketLoss = existing.MaxPacketLoss
+	}
+	if target.MaxRedirects == 0 {
+		target.MaxRedirects = existing.MaxRedirects
+	}
+	if target.Timeout == 0 {
+		target.Timeout = existing.Timeout
+	}
+	if target.Body == "" {
+		target.Body = existing.Body
+	}
+	if target.BodyMatch == "" {
+		target.BodyMatch = existing.BodyMatch
+	}
+	if target.PhaseThresholds == nil {
+		target.PhaseThresholds = existing.PhaseThresholds
+	}
+	if target.MaxBodyReadKB == 0 {
+		target.MaxBodyReadKB = existing.MaxBodyReadKB
+	}
+	if target.MaxBodyStoreKB == 0 {
+		target.MaxBodyStoreKB = existing.MaxBodyStoreKB
+	}
+	if target.Interval == 0 {
+		target.Interval = existing.Interval
+	}
+	// retry_on_failure is an alias of retries, so setting it keeps the stored count out
+	if target.Retries == 0 && target.RetryOnFailure == 0 {
+		target.Retries = existing.Retries
+	}
+	if target.MaxResponseTime == 0 {
+		target.MaxResponseTime = existing.MaxResponseTime
+	}
+	if target.SlowAlerts == nil {
+		target.SlowAlerts = existing.SlowAlerts
+	}
+	if target.AlertRoutes == nil {
+		target.AlertRoutes = existing.AlertRoutes
+	}
+	if target.InitialGraceSeconds == 0 {
+		target.InitialGraceSeconds = existing.InitialGraceSeconds
+	}
+	if target.RecoveryThreshold == 0 {
+		target.RecoveryThreshold = existing.RecoveryThreshold
+	}
+	if target.RecoveryCooldownSeconds == 0 {
+		target.RecoveryCooldownSeconds = existing.RecoveryCooldownSeconds
+	}
+	if target.CheckHistorySize == 0 {
+		target.CheckHistorySize = existing.CheckHistorySize
+	}
+	if target.RequireAckForAutoresolve == nil {
+		target.RequireAckForAutoresolve = existing.RequireAckForAutoresolve
+	}
+	if target.FollowRedirects == nil {
+		target.FollowRedirects = existing.FollowRedirects
+	}
+	if target.ContentAlerts == nil {
+		target.ContentAlerts = existing.ContentAlerts
+	}
+	if target.Extract == nil {
+		target.Extract = existing.Extract
+	}
+	if target.JSONAssertions == nil {
+		target.JSONAssertions = existing.JSONAssertions
+	}
+	if target.Escalation == nil {
+		target.Escalation = existing.Escalation
+	}
+	if target.IPVersion == "" {
+		target.IPVersion = existing.IPVersion
+	}
+	if target.DownAfterFailures == 0 && target.Threshold == 0 {
+		target.DownAfterFailures = existing.DownAfterFailures
+	}
+	if target.SlackChannel == "" {
+		target.SlackChannel = existing.SlackChannel
+	}
+	if target.SlackMention == "" {
+		target.SlackMention = existing.SlackMention
+	}
+	if target.ExpectedContentType == "" {
+		target.ExpectedContentType = existing.ExpectedContentType
+	}
+	if target.Callback == nil {
+		target.Callback = existing.Callback
+	}
+	if target.URLs == nil {
+		target.URLs = existing.URLs
+	}
+	if target.BackendPolicy == "" {
+		target.BackendPolicy = existing.BackendPolicy
+	}
+	if target.BackendQuorum == 0 {
+		target.BackendQuorum = existing.BackendQuorum
+	}
+	if target.Tags == nil {
+		target.Tags = existing.Tags
+	}
+	if target.AlertMessageTemplate == "" {
+		target.AlertMessageTemplate = existing.AlertMessageTemplate
+	}
+	if target.Cookies == nil {
+		target.Cookies = existing.Cookies
+	}
+	if target.DependsOn == nil {
+		target.DependsOn = existing.DependsOn
+	}
+	if target.Severity == "" {
+		target.Severity = existing.Severity
+	}
 }
 
 // validateAlertsYAML validates that the alerts YAML is well-formed
@@ -1688,30 +2795,44 @@ func validateAlerts(alerts map[string]NotifierConfig) error {
 			return fmt.Errorf("alert %s: type is required", name)
 		}
 
+		if v, ok := alert.Settings["max_message_length"]; ok {
+			n, isInt := yamlInt(v)
+			if !isInt || n < 0 {
+				return fmt.Errorf("alert %s: max_message_length must be a non-negative integer (0 = unlimited)", name)
+			}
+		}
+		if _, err := parseNotifierSchedule(alert.Settings); err != nil {
+			return fmt.Errorf("alert %s: %v", name, err)
+		}
+		if fallback, ok := alert.Settings["fallback_alert"].(string); ok && fallback != "console" {
+			if _, exists := alerts[fallback]; !exists || fallback == name {
+				return fmt.Errorf("alert %s: fallback_alert must name another alert or 'console', got '%s'", name, fallback)
+			}
+		}
+
 		switch alert.Type {
 		case "console":
 			// Validate console settings
 			if style, ok := alert.Settings["style"].(string); ok {
-				if style != "plain" && style != "stylized" {
-					return fmt.Errorf("alert %s: console style must be 'plain' or 'stylized', got '%s'", name, style)
+				if style != "plain" && style != "stylized" && style != "compact" {
+					return fmt.Errorf("alert %s: console style must be 'plain', 'stylized' or 'compact', got '%s'", name, style)
 				}
 			}
 		case "slack":
 			// Validate Slack settings
-			webhookURL, ok := alert.Settings["webhook_url"].(string)
-			if !ok || webhookURL == "" {
-				return fmt.Errorf("alert %s: slack webhook_url is required", name)
-			}
-			if !strings.HasPrefix(webhookURL, "https://hooks.slack.com/") {
-				return fmt.Errorf("alert %s: slack webhook_url must be a valid Slack webhook URL", name)
+			if err := validateSlackSettings(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: %v", name, err)
 			}
 		case "email":
 			// Validate Email settings
 			if host, ok := alert.Settings["smtp_host"].(string); !ok || strings.TrimSpace(host) == "" {
 				return fmt.Errorf("alert %s: email smtp_host is required", name)
 			}
-			if to, ok := alert.Settings["to"].(string); !ok || strings.TrimSpace(to) == "" {
-				return fmt.Errorf("alert %s: email to is required", name)
+			if err := validateEmailRecipients(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: %v", name, err)
+			}
+			if err := validateSMTPTLSSettings(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: %v", name, err)
 			}
 			if _, ok := alert.Settings["smtp_port"].(int); !ok {
 				if _, okf := alert.Settings["smtp_port"].(float64); !okf {
@@ -1727,8 +2848,29 @@ func validateAlerts(alerts map[string]NotifierConfig) error {
 			if filePath, ok := alert.Settings["file_path"].(string); !ok || strings.TrimSpace(filePath) == "" {
 				return fmt.Errorf("alert %s: file file_path is required", name)
 			}
+		case "webhook":
+			// Validate Webhook settings
+			webhookURL, ok := alert.Settings["webhook_url"].(string)
+			if !ok || strings.TrimSpace(webhookURL) == "" {
+				return fmt.Errorf("alert %s: webhook webhook_url is required", name)
+			}
+			if !strings.HasPrefix(webhookURL, "http://") && !strings.HasPrefix(webhookURL, "https://") {
+				return fmt.Errorf("alert %s: webhook webhook_url must start with http:// or https://", name)
+			}
+			if err := validateWebhookAuth(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: webhook %v", name, err)
+			}
+			if _, err := parseWebhookBodyTemplate(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: webhook %v", name, err)
+			}
+			if _, err := webhookHeadersFromSettings(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: webhook %v", name, err)
+			}
+			if _, err := webhookTLSConfigFromSettings(alert.Settings); err != nil {
+				return fmt.Errorf("alert %s: webhook %v", name, err)
+			}
 		default:
-			return fmt.Errorf("alert %s: unknown type '%s', must be 'console', 'slack', 'email', or 'file'", name, alert.Type)
+			return fmt.Errorf("alert %s: unknown type '%s', must be 'console', 'slack', 'email', 'file', or 'webhook'", name, alert.Type)
 		}
 	}
 	return nil
@@ -1787,15 +2929,27 @@ func validateStateFile(stateFile string, verbose bool) {
 	for name, alert := range alerts {
 		if alert.Enabled {
 			if alert.Type == "slack" {
-				if webhookURL, ok := alert.Settings["webhook_url"].(string); !ok || webhookURL == "" {
-					errors = append(errors, fmt.Sprintf("Notifier %s: slack webhook_url is required", name))
-				} else if !strings.HasPrefix(webhookURL, "https://hooks.slack.com/") {
-					errors = append(errors, fmt.Sprintf("Notifier %s: slack webhook_url must be a valid Slack webhook URL", name))
+				if err := validateSlackSettings(alert.Settings); err != nil {
+					errors = append(errors, fmt.Sprintf("Notifier %s: %v", name, err))
 				}
 			}
 		}
 	}
 
+	// Check that hooks reference notifiers able to deliver hook notifications
+	for hookName, hook := range stateManager.ListHooks() {
+		unknown := unknownHookAlerts(hook, func(name string) bool {
+			if name == "console" {
+				return true
+			}
+			alert, exists := alerts[name]
+			return exists && alert.Enabled && hookNotifierTypes[alert.Type]
+		})
+		for _, name := range unknown {
+			warnings = append(warnings, fmt.Sprintf("Hook %s: alert '%s' is not an enabled console, slack or email notifier and will be skipped", hookName, name))
+		}
+	}
+
 	// Print results
 	if len(errors) == 0 && len(warnings) == 0 {
 		fmt.Printf("%s Configuration is valid!\n", qc.Colorize("✅ Success:", qc.ColorGreen))
@@ -1803,7 +2957,7 @@ func validateStateFile(stateFile string, verbose bool) {
 			fmt.Printf("  • %d targets configured\n", len(targets))
 			fmt.Printf("  • %d alerts configured\n", len(alerts))
 		}
-		os.Exit(0)
+		return
 	}
 
 	// Print warnings
@@ -1861,7 +3015,7 @@ func validateConfigFile(configFile string, verbose bool) {
 					if method, ok := targetMap["method"].(string); ok {
 						target.Method = method
 					}
-					if threshold, ok := targetMap["threshold"].(int); ok {
+					if threshold, ok := yamlInt(targetMap["threshold"]); ok {
 						target.Threshold = threshold
 					}
 					if checkStrategy, ok := targetMap["check_strategy"].(string); ok {
@@ -1936,10 +3090,8 @@ func validateConfigFile(configFile string, verbose bool) {
 	for name, alert := range alerts {
 		if alert.Enabled {
 			if alert.Type == "slack" {
-				if webhookURL, ok := alert.Settings["webhook_url"].(string); !ok || webhookURL == "" {
-					errors = append(errors, fmt.Sprintf("Notifier %s: slack webhook_url is required", name))
-				} else if !strings.HasPrefix(webhookURL, "https://hooks.slack.com/") {
-					errors = append(errors, fmt.Sprintf("Notifier %s: slack webhook_url must be a valid Slack webhook URL", name))
+				if err := validateSlackSettings(alert.Settings); err != nil {
+					errors = append(errors, fmt.Sprintf("Notifier %s: %v", name, err))
 				}
 			}
 		}
@@ -1952,7 +3104,7 @@ func validateConfigFile(configFile string, verbose bool) {
 			fmt.Printf("  • %d targets configured\n", len(targets))
 			fmt.Printf("  • %d alerts configured\n", len(alerts))
 		}
-		os.Exit(0)
+		return
 	}
 
 	// Print warnings
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -111,3 +113,86 @@ func TestEdit_PersistsTargetsToState(t *testing.T) {
 		t.Fatalf("expected 1 persisted target, got %d", len(got))
 	}
 }
+
+func TestParseTargets_CoercesNumericForms(t *testing.T) {
+	yaml := []byte(
+		"my-target:\n" +
+			"  url: https://example.com/health\n" +
+			"  threshold: 45.0\n" +
+			"  size_alerts:\n" +
+			"    history_size: \"50\"\n" +
+			"    threshold: 1\n",
+	)
+	targets, _, err := parseTargetsFromYAML(yaml)
+	if err != nil {
+		t.Fatalf("parseTargetsFromYAML error: %v", err)
+	}
+	got := targets["https://example.com/health"]
+	if got.Threshold != 45 {
+		t.Errorf("expected float threshold to coerce to 45, got %d", got.Threshold)
+	}
+	if got.SizeAlerts.HistorySize != 50 || got.SizeAlerts.Threshold != 1 {
+		t.Errorf("unexpected size alerts: %+v", got.SizeAlerts)
+	}
+
+	settings := parseSettingsData(map[string]any{"check_interval": "10", "default_threshold": 60.0})
+	if settings.CheckInterval != 10 || settings.DefaultThreshold != 60 {
+		t.Errorf("settings not coerced: check_interval=%d default_threshold=%d", settings.CheckInterval, settings.DefaultThreshold)
+	}
+	if _, ok := yamlInt(30.5); ok {
+		t.Errorf("expected fractional value to be rejected")
+	}
+}
+
+func TestParseTargets_DefaultsAndIncludes(t *testing.T) {
+	dir := t.TempDir()
+	shared := "targets:\n" +
+		"  shared:\n" +
+		"    name: Shared\n" +
+		"    url: https://shared.example.com/health\n" +
+		"    threshold: 120\n"
+	if err := os.WriteFile(filepath.Join(dir, "shared.yml"), []byte(shared), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	main := []byte("include: [shared.yml]\n" +
+		"defaults:\n" +
+		"  threshold: 60\n" +
+		"  alerts: [console]\n" +
+		"  headers: {X-Team: payments, X-Env: prod}\n" +
+		"targets:\n" +
+		"  api:\n" +
+		"    name: API\n" +
+		"    url: https://api.example.com/health\n" +
+		"    headers: {X-Env: staging}\n")
+
+	config, err := LoadYAMLConfigFrom(main, dir)
+	if err != nil {
+		t.Fatalf("LoadYAMLConfigFrom error: %v", err)
+	}
+	byName := map[string]Target{}
+	for _, target := range config.Targets {
+		byName[target.Name] = target
+	}
+	api, sharedTarget := byName["API"], byName["Shared"]
+	if api.Threshold != 60 || len(api.Alerts) != 1 || api.Headers["X-Team"] != "payments" || api.Headers["X-Env"] != "staging" {
+		t.Errorf("expected defaults merged under the target's own values, got %+v", api)
+	}
+	if sharedTarget.Threshold != 120 || sharedTarget.Headers["X-Team"] != "payments" {
+		t.Errorf("expected the included target to get defaults without losing its threshold, got %+v", sharedTarget)
+	}
+
+	targets, _, err := parseTargetsFromYAML([]byte("defaults: {threshold: 90}\ntargets:\n  a:\n    url: https://a.example.com\n"))
+	if err != nil || targets["https://a.example.com"].Threshold != 90 {
+		t.Errorf("expected the editor parser to apply defaults, got %+v (err %v)", targets, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "loop.yml"), []byte("include: loop.yml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadYAMLConfigFrom([]byte("include: loop.yml\n"), dir); err == nil || !strings.Contains(err.Error(), "includes itself") {
+		t.Errorf("expected an include cycle to be rejected, got %v", err)
+	}
+	if _, err := LoadYAMLConfigFrom([]byte("defaults: {url: https://x}\ntargets: {}\n"), dir); err == nil {
+		t.Errorf("expected url in defaults to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// eventSubscriberBuffer is how many events a slow subscriber may fall behind
+// before further events are dropped for it
+const eventSubscriberBuffer = 64
+
+// TargetEvent is a single live update pushed to /api/events subscribers
+type TargetEvent struct {
+	Type           string    `json:"type"` // "check" for every result, "state" when is_down flips
+	Name           string    `json:"name"`
+	URL            string    `json:"url"`
+	URLSafe        string    `json:"url_safe"`
+	IsDown         bool      `json:"is_down"`
+	Timestamp      time.Time `json:"timestamp"`
+	Success        bool      `json:"success"`
+	ResponseTimeMs int64     `json:"response_time_ms"`
+	StatusCode     int       `json:"status_code,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	Slow           bool      `json:"slow,omitempty"`
+}
+
+// EventBroadcaster fans target events out to any number of subscribers
+// without ever blocking the check loop
+type EventBroadcaster struct {
+	subscribers map[chan TargetEvent]struct{}
+	mutex       sync.Mutex
+}
+
+// NewEventBroadcaster creates a broadcaster with no subscribers
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{subscribers: make(map[chan TargetEvent]struct{})}
+}
+
+// Subscribe registers a new subscriber; call the returned function to unsubscribe
+func (b *EventBroadcaster) Subscribe() (<-chan TargetEvent, func()) {
+	ch := make(chan TargetEvent, eventSubscriberBuffer)
+	b.mutex.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	return ch, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish delivers an event to every subscriber, dropping it for any
+// subscriber whose buffer is full
+func (b *EventBroadcaster) Publish(event TargetEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// publishCheckEvents emits a "check" event for the entry just recorded and,
+// if the target went up or down, a "state" event as well
+func (e *TargetEngine) publishCheckEvents(state *TargetState, entry CheckHistoryEntry, wasDown bool) {
+	if e.events == nil {
+		return
+	}
+	event := TargetEvent{
+		Type:           "check",
+		Name:           state.Target.Name,
+		URL:            state.Target.URL,
+		URLSafe:        state.GetURLSafeName(),
+		IsDown:         state.IsDown,
+		Timestamp:      entry.Timestamp,
+		Success:        entry.Success,
+		ResponseTimeMs: entry.ResponseTime,
+		StatusCode:     entry.StatusCode,
+		Error:          entry.ErrorMessage,
+		Slow:           entry.Slow,
+	}
+	e.events.Publish(event)
+	if wasDown != state.IsDown {
+		event.Type = "state"
+		e.events.Publish(event)
+	}
+}
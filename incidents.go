@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxIncidentLogEntries bounds the incident log; the oldest entries are dropped first
+const maxIncidentLogEntries = 1000
+
+// Incident is one outage of a target: opened once it has been failing past its threshold
+// and closed when it recovers
+type Incident struct {
+	ID              int        `json:"id"`
+	Target          string     `json:"target"`
+	URL             string     `json:"url"`
+	Tags            []string   `json:"tags,omitempty"`
+	StartedAt       time.Time  `json:"started_at"` // when the target started failing
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds"` // so far, for open incidents
+	Error           string     `json:"error,omitempty"`  // failure that opened the incident
+	Acknowledged    bool       `json:"acknowledged"`
+	AcknowledgedBy  string     `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt  *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// Open reports whether the incident has not recovered yet
+func (i Incident) Open() bool {
+	return i.ResolvedAt == nil
+}
+
+// IncidentLog records incidents and persists them as JSON next to the state file, so
+// they outlive restarts unlike check history
+type IncidentLog struct {
+	path      string // "" keeps incidents in memory only
+	mutex     sync.Mutex
+	incidents []Incident      // oldest first
+	open      map[string]bool // target names with an open incident
+	nextID    int
+}
+
+// incidentLogPath returns the incident log kept beside statePath ("watch-state.yml" ->
+// "watch-state.incidents.json"), or "" when state is piped through stdin/stdout
+func incidentLogPath(statePath string) string {
+	if statePath == "" || statePath == stdioStatePath {
+		return ""
+	}
+	return strings.TrimSuffix(statePath, filepath.Ext(statePath)) + ".incidents.json"
+}
+
+// LoadIncidents reads the incidents stored at path; a missing file holds none
+func LoadIncidents(path string) ([]Incident, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read incident log: %v", err)
+	}
+	var incidents []Incident
+	if err := json.Unmarshal(data, &incidents); err != nil {
+		return nil, fmt.Errorf("failed to parse incident log %s: %v", path, err)
+	}
+	return incidents, nil
+}
+
+// NewIncidentLog creates an incident log backed by path, loading the incidents already there
+func NewIncidentLog(path string) *IncidentLog {
+	l := &IncidentLog{path: path, open: make(map[string]bool), nextID: 1}
+	if path == "" {
+		return l
+	}
+	incidents, err := LoadIncidents(path)
+	if err != nil {
+		log.Printf("Warning: %v; starting a new incident log", err)
+	}
+	l.incidents = incidents
+	for _, incident := range incidents {
+		if incident.Open() {
+			l.open[incident.Target] = true
+		}
+		l.nextID = max(l.nextID, incident.ID+1)
+	}
+	return l
+}
+
+// Open starts an incident for target unless one is already open (an outage that spans a
+// restart continues the incident it began)
+func (l *IncidentLog) Open(target *Target, startedAt time.Time, reason string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.open[target.Name] {
+		return
+	}
+	l.incidents = append(l.incidents, Incident{
+		ID:        l.nextID,
+		Target:    target.Name,
+		URL:       target.URL,
+		Tags:      target.Tags,
+		StartedAt: startedAt,
+		Error:     reason,
+	})
+	l.nextID++
+	l.open[target.Name] = true
+	if len(l.incidents) > maxIncidentLogEntries {
+		l.incidents = slices.Delete(l.incidents, 0, len(l.incidents)-maxIncidentLogEntries)
+	}
+	l.saveLocked()
+}
+
+// Acknowledge records who acknowledged the target's open incident
+func (l *IncidentLog) Acknowledge(targetName, acknowledgedBy string, at time.Time) {
+	l.update(targetName, func(incident *Incident) {
+		if !incident.Acknowledged {
+			incident.Acknowledged = true
+			incident.AcknowledgedAt = &at
+		}
+		if acknowledgedBy != "" {
+			incident.AcknowledgedBy = acknowledgedBy
+		}
+	})
+}
+
+// Resolve closes the target's open incident, if any
+func (l *IncidentLog) Resolve(targetName string, at time.Time) {
+	l.update(targetName, func(incident *Incident) {
+		incident.ResolvedAt = &at
+		incident.DurationSeconds = int64(at.Sub(incident.StartedAt).Seconds())
+		delete(l.open, targetName)
+	})
+}
+
+// Rename moves the open incident of the target formerly named oldName to target, so the
+// outage keeps one incident across the rename
+func (l *IncidentLog) Rename(oldName string, target *Target) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if oldName == target.Name || !l.open[oldName] || l.open[target.Name] {
+		return
+	}
+	for i := len(l.incidents) - 1; i >= 0; i-- {
+		if l.incidents[i].Target == oldName && l.incidents[i].Open() {
+			l.incidents[i].Target = target.Name
+			l.incidents[i].URL = target.URL
+			break
+		}
+	}
+	delete(l.open, oldName)
+	l.open[target.Name] = true
+	l.saveLocked()
+}
+
+// ResolveRemoved closes the open incidents of targets not in names, which would otherwise
+// never recover
+func (l *IncidentLog) ResolveRemoved(names map[string]bool, at time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	changed := false
+	for i := range l.incidents {
+		incident := &l.incidents[i]
+		if incident.Open() && !names[incident.Target] {
+			incident.ResolvedAt = &at
+			incident.DurationSeconds = int64(at.Sub(incident.StartedAt).Seconds())
+			delete(l.open, incident.Target)
+			changed = true
+		}
+	}
+	if changed {
+		l.saveLocked()
+	}
+}
+
+// update applies change to the target's open incident and saves the log
+func (l *IncidentLog) update(targetName string, change func(incident *Incident)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if !l.open[targetName] {
+		return
+	}
+	for i := len(l.incidents) - 1; i >= 0; i-- {
+		if l.incidents[i].Target == targetName && l.incidents[i].Open() {
+			change(&l.incidents[i])
+			l.saveLocked()
+			return
+		}
+	}
+	delete(l.open, targetName)
+}
+
+// List returns the incidents matching target ("" for all) and status ("open",
+// "resolved" or "" for both), newest first and at most limit of them (0 for no limit)
+func (l *IncidentLog) List(target, status string, limit int) []Incident {
+	l.mutex.Lock()
+	incidents := slices.Clone(l.incidents)
+	l.mutex.Unlock()
+	return filterIncidents(incidents, target, status, limit, time.Now())
+}
+
+// filterIncidents selects incidents newest first, filling in how long open ones have lasted
+func filterIncidents(incidents []Incident, target, status string, limit int, now time.Time) []Incident {
+	var selected []Incident
+	for i := len(incidents) - 1; i >= 0; i-- {
+		incident := incidents[i]
+		if target != "" && !strings.EqualFold(incident.Target, target) && incident.URL != target {
+			continue
+		}
+		if (status == "open" && !incident.Open()) || (status == "resolved" && incident.Open()) {
+			continue
+		}
+		if incident.Open() {
+			incident.DurationSeconds = int64(now.Sub(incident.StartedAt).Seconds())
+		}
+		selected = append(selected, incident)
+		if limit > 0 && len(selected) == limit {
+			break
+		}
+	}
+	return selected
+}
+
+// saveLocked writes the log to disk; a failure is logged rather than interrupting checks
+func (l *IncidentLog) saveLocked() {
+	if l.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(l.incidents, "", "  ")
+	if err != nil {
+		log.Printf("Warning: failed to encode incident log: %v", err)
+		return
+	}
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		log.Printf("Warning: failed to write incident log %s: %v", l.path, err)
+	}
+}
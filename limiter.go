@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CheckLimiter bounds how many checks run at once. Checks that find every slot
+// taken queue in arrival order rather than being skipped, and the limit can be
+// changed while checks are running or waiting.
+type CheckLimiter struct {
+	limit   int // 0 = unlimited
+	active  int
+	waiters []chan struct{} // closed when the waiter is granted a slot
+	mutex   sync.Mutex
+}
+
+// NewCheckLimiter creates a limiter allowing limit concurrent checks (0 = unlimited)
+func NewCheckLimiter(limit int) *CheckLimiter {
+	return &CheckLimiter{limit: max(limit, 0)}
+}
+
+// Acquire waits for a free slot; call Release when the check is done. It returns
+// ctx's error, holding no slot, if ctx ends first.
+func (l *CheckLimiter) Acquire(ctx context.Context) error {
+	l.mutex.Lock()
+	if len(l.waiters) == 0 && l.hasFreeSlotLocked() {
+		l.active++
+		l.mutex.Unlock()
+		return nil
+	}
+	granted := make(chan struct{})
+	l.waiters = append(l.waiters, granted)
+	l.mutex.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if i := slices.Index(l.waiters, granted); i >= 0 {
+			l.waiters = slices.Delete(l.waiters, i, i+1)
+		} else {
+			// Granted while giving up; hand the slot to the next waiter
+			l.active--
+			l.grantLocked()
+		}
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes a free slot without waiting, reporting whether it got one; call
+// Release when it did. It never jumps ahead of queued waiters.
+func (l *CheckLimiter) TryAcquire() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(l.waiters) > 0 || !l.hasFreeSlotLocked() {
+		return false
+	}
+	l.active++
+	return true
+}
+
+// Release frees a slot taken by Acquire or TryAcquire
+func (l *CheckLimiter) Release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.active--
+	l.grantLocked()
+}
+
+// SetLimit changes the limit; raising it starts queued checks immediately, while
+// lowering it lets running checks finish before new ones start
+func (l *CheckLimiter) SetLimit(limit int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.limit = max(limit, 0)
+	l.grantLocked()
+}
+
+// Stats returns the checks currently running and waiting for a slot
+func (l *CheckLimiter) Stats() (active, waiting int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.active, len(l.waiters)
+}
+
+func (l *CheckLimiter) hasFreeSlotLocked() bool {
+	return l.limit == 0 || l.active < l.limit
+}
+
+// grantLocked hands free slots to waiters in arrival order; callers hold l.mutex
+func (l *CheckLimiter) grantLocked() {
+	for len(l.waiters) > 0 && l.hasFreeSlotLocked() {
+		l.active++
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+	}
+}
+
+// TriggerThrottle enforces a minimum interval between successful manual triggers, tracked
+// per key (a webhook target, a target's check or the status report)
+type TriggerThrottle struct {
+	last  map[string]time.Time
+	mutex sync.Mutex
+}
+
+// maxThrottleKeys bounds how many keys are tracked before expired ones are pruned
+const maxThrottleKeys = 1024
+
+// NewTriggerThrottle creates an empty trigger throttle
+func NewTriggerThrottle() *TriggerThrottle {
+	return &TriggerThrottle{last: make(map[string]time.Time)}
+}
+
+// Wait returns how long until key can be triggered again at now, or 0 if it can be
+// triggered. An interval of 0 accepts everything.
+func (t *TriggerThrottle) Wait(key string, interval time.Duration, now time.Time) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if last, ok := t.last[key]; ok {
+		return max(last.Add(interval).Sub(now), 0)
+	}
+	return 0
+}
+
+// Record starts key's cooldown at now; call it once a trigger has succeeded, so failed
+// triggers don't hold back the next attempt
+func (t *TriggerThrottle) Record(key string, interval time.Duration, now time.Time) {
+	if interval <= 0 {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.last) >= maxThrottleKeys {
+		for k, last := range t.last {
+			if now.Sub(last) >= interval {
+				delete(t.last, k)
+			}
+		}
+	}
+	t.last[key] = now
+}
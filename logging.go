@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Values accepted by the log_format setting
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// jsonLogWriter turns standard log output into one JSON object per line, using the
+// same keys FileAlertStrategy writes (timestamp, level, service.name, target.name)
+type jsonLogWriter struct {
+	out       io.Writer
+	prevFlags int // restored when switching back to text
+	mutex     sync.Mutex
+}
+
+// jsonLog is the active JSON writer, or nil while logging plain text
+var jsonLog atomic.Pointer[jsonLogWriter]
+
+// Write wraps one line from the standard logger
+func (w *jsonLogWriter) Write(p []byte) (int, error) {
+	if err := w.writeEntry("", "", strings.TrimRight(string(p), "\n")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *jsonLogWriter) writeEntry(event, target, message string) error {
+	entry := map[string]any{
+		"timestamp":    inDisplayZone(time.Now()).Format(time.RFC3339Nano),
+		"level":        logLevel(message),
+		"service.name": "quick_watch",
+		"message":      message,
+	}
+	if event != "" {
+		entry["event"] = event
+	}
+	if target != "" {
+		entry["target.name"] = target
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err = w.out.Write(append(line, '\n'))
+	return err
+}
+
+// logLevel infers a level from the wording the server's log lines already use
+func logLevel(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "warning"):
+		return "warn"
+	case strings.Contains(lower, "error"), strings.Contains(lower, "failed"):
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// zoneLogWriter stamps text log lines with the time in the configured timezone, taking
+// over the standard logger's date and time flags, which only know local time and UTC
+type zoneLogWriter struct {
+	out       io.Writer
+	prevFlags int // restored when the zone changes
+}
+
+// zoneLog is the active timestamping writer, or nil while the logger stamps lines itself
+var zoneLog atomic.Pointer[zoneLogWriter]
+
+// Write prefixes one line from the standard logger with the flags' timestamp layout
+func (w *zoneLogWriter) Write(p []byte) (int, error) {
+	layout := ""
+	if w.prevFlags&log.Ldate != 0 {
+		layout = "2006/01/02 "
+	}
+	if w.prevFlags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout += "15:04:05"
+		if w.prevFlags&log.Lmicroseconds != 0 {
+			layout += ".000000"
+		}
+		layout += " "
+	}
+	line := append([]byte(inDisplayZone(time.Now()).Format(layout)), p...)
+	if _, err := w.out.Write(line); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// unwrapZoneLog removes the timestamping writer, restoring the logger's own flags
+func unwrapZoneLog() {
+	if w := zoneLog.Swap(nil); w != nil {
+		log.SetOutput(w.out)
+		log.SetFlags(w.prevFlags)
+	}
+}
+
+// configureLogTimestamps stamps text log lines in location. The logger's LUTC flag covers
+// UTC and a writer covers other zones; JSON lines already carry the zone's offset.
+func configureLogTimestamps(location *time.Location) {
+	unwrapZoneLog()
+	if jsonLog.Load() != nil {
+		return
+	}
+	if location == time.UTC {
+		log.SetFlags(log.Flags() | log.LUTC)
+		return
+	}
+	log.SetFlags(log.Flags() &^ log.LUTC)
+	if location == time.Local {
+		return
+	}
+	w := &zoneLogWriter{out: log.Writer(), prevFlags: log.Flags()}
+	zoneLog.Store(w)
+	log.SetFlags(w.prevFlags &^ (log.Ldate | log.Ltime | log.Lmicroseconds))
+	log.SetOutput(w)
+}
+
+// configureLogging switches the standard logger between text and JSON lines
+func configureLogging(format string) {
+	if format == logFormatJSON {
+		if jsonLog.Load() != nil {
+			return
+		}
+		unwrapZoneLog()
+		w := &jsonLogWriter{out: log.Writer(), prevFlags: log.Flags()}
+		jsonLog.Store(w)
+		log.SetFlags(0)
+		log.SetOutput(w)
+		return
+	}
+	if w := jsonLog.Swap(nil); w != nil {
+		log.SetOutput(w.out)
+		log.SetFlags(w.prevFlags)
+	}
+}
+
+// logEvent logs like log.Printf; in JSON mode the line also carries the event name
+// and, when set, the target it concerns
+func logEvent(event, target, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if w := jsonLog.Load(); w != nil {
+		w.writeEntry(event, target, message)
+		return
+	}
+	log.Print(message)
+}
@@ -6,24 +6,48 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	qc "github.com/bevelwork/quick_color"
 	versionpkg "github.com/bevelwork/quick_watch/version"
+	"gopkg.in/yaml.v3"
 )
 
 var version = ""
 
 func main() {
+	// With --state -, stdout carries only the state; everything else goes to stderr
+	if len(os.Args) > 2 && getStateFile(os.Args[2:]) == stdioStatePath {
+		if slices.Contains(os.Args[2:], "--stdin") {
+			fmt.Fprintf(os.Stderr, "%s --state - reads state from stdin and cannot be combined with --stdin\n", qc.Colorize("❌ Error:", qc.ColorRed))
+			os.Exit(1)
+		}
+		if os.Args[1] == "server" {
+			fmt.Fprintf(os.Stderr, "%s --state - is not supported by server; use a state file\n", qc.Colorize("❌ Error:", qc.ColorRed))
+			os.Exit(1)
+		}
+		stateOutput = os.Stdout
+		os.Stdout = os.Stderr
+	}
+
 	// Print header
 	printHeader()
 
@@ -61,27 +85,61 @@ func main() {
 		handleAddCommand(args)
 	case "rm":
 		handleRemoveCommand(args)
+	case "pause", "resume":
+		handlePauseCommand(args, action == "pause")
 	case "list":
 		handleListCommand(args)
 	case "config":
 		handleConfigCommand(args)
+	case "export":
+		handleExportCommand(args)
+	case "import":
+		handleImportCommand(args)
 	case "server":
 		handleServerCommand(args)
+	case "check", "test":
+		if action == "test" && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			// "test <notifier>" fires a sample alert; "test --once" stays an alias of check
+			handleTestNotifierCommand(args[0], args[1:])
+		} else if action == "check" && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			// "check <url>" probes a single URL without the state file's targets
+			handleCheckURLCommand(args[0], args[1:])
+		} else {
+			handleCheckCommand(args)
+		}
+	case "paging":
+		handlePagingCommand(args)
+	case "history":
+		handleHistoryCommand(args)
+	case "incidents":
+		handleIncidentsCommand(args)
 	default:
 		fmt.Printf("%s Unknown action: %s\n", qc.Colorize("❌ Error:", qc.ColorRed), action)
 		showHelp()
 		os.Exit(1)
 	}
 
+	// With --state -, the state is written once the action has finished saving it
+	if err := flushStdoutState(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
 }
 
 // showHelp displays the help information
 func showHelp() {
 	fmt.Printf("Usage: %s <action> [options]\n\n", os.Args[0])
 	fmt.Println("Simple Actions:")
-	fmt.Println("  add <url>     Add a target with default settings")
+	fmt.Println("  add <url>     Add a target with default settings (--on-duplicate error|overwrite|merge)")
+	fmt.Println("  add --file <file>  Add one target per line of a file of URLs (a line may add its own flags)")
 	fmt.Println("  rm <url>      Remove a target")
+	fmt.Println("  rm --all --yes  Remove every target, or only those matching --match <text> (lists them without --yes)")
+	fmt.Println("  pause <url>   Stop checking and alerting on a target, keeping its config and history")
+	fmt.Println("  resume <url>  Start checking a paused target again")
 	fmt.Println("  list          List all targets")
+	fmt.Println("  list --watch  Live status table from the running server (--interval N, --server URL, --local)")
+	fmt.Println("  history <url> Show a target's recent checks from the running server (--limit N, --json)")
+	fmt.Println("  incidents     List recorded outages (--target NAME, --open, --resolved, --limit N, --json)")
 	fmt.Println("  server        Start the server")
 	fmt.Println("")
 	fmt.Println("Advanced Actions:")
@@ -91,15 +149,35 @@ func showHelp() {
 	fmt.Println("")
 	fmt.Println("Administrative Actions:")
 	fmt.Println("  validate      Validate configuration syntax and alert strategies")
+	fmt.Println("  validate --dry-run  Also check every target once and report live results")
+	fmt.Println("  check --once  Check every target once and exit non-zero on failure")
+	fmt.Println("  check <url>   Check one URL --count times, --interval apart (--method, --header, --status-codes)")
+	fmt.Println("  test <alert>  Send a sample alert and all-clear through a configured alert")
+	fmt.Println("  paging <mode> Set paging mode on a running server: critical-only, all, or status")
 	fmt.Println("  config <file> Use YAML configuration file")
+	fmt.Println("  config --effective  Print the resolved configuration with secrets masked")
+	fmt.Println("  config --canonical  Print the stored configuration sorted for diffing (--format yaml|json)")
+	fmt.Println("  export <file> Write targets, settings, alerts and hooks to a portable bundle")
+	fmt.Println("  import <file> Validate a bundle and replace the configuration with it (--merge to add to it)")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Printf("  %s targets\n", os.Args[0])
 	fmt.Printf("  %s add https://api.example.com/health --threshold 30s\n", os.Args[0])
+	fmt.Printf("  %s add --file urls.txt --alert-strategy slack-alerts\n", os.Args[0])
 	fmt.Printf("  %s rm https://api.example.com/health\n", os.Args[0])
+	fmt.Printf("  %s rm --all --match staging --yes\n", os.Args[0])
+	fmt.Printf("  %s pause https://staging.example.com/health\n", os.Args[0])
 	fmt.Printf("  %s list\n", os.Args[0])
+	fmt.Printf("  %s list --watch --interval 2\n", os.Args[0])
+	fmt.Printf("  %s history https://api.example.com/health --limit 50\n", os.Args[0])
+	fmt.Printf("  %s incidents --target \"Payments API\" --open\n", os.Args[0])
 	fmt.Printf("  %s config\n", os.Args[0])
 	fmt.Printf("  %s server --webhook-port 8080\n", os.Args[0])
+	fmt.Printf("  %s check --once --concurrency 5 --tolerance 1\n", os.Args[0])
+	fmt.Printf("  %s check https://api.example.com/health --count 5 --interval 2s --status-codes 200\n", os.Args[0])
+	fmt.Printf("  %s test my-slack-alert\n", os.Args[0])
+	fmt.Printf("  %s export quick_watch-bundle.yml\n", os.Args[0])
+	fmt.Printf("  %s import quick_watch-bundle.yml --merge\n", os.Args[0])
 }
 
 // handleEditCommand handles the edit action
@@ -124,42 +202,156 @@ func handleEditCommand(args []string) {
 
 // handleAddCommand handles the add action
 func handleAddCommand(args []string) {
-	if len(args) == 0 {
+	if path := getStringFlag(args, "--file", ""); path != "" {
+		handleAddFile(getStateFile(args), path, args, getStringFlag(args, "--on-duplicate", ""))
+		return
+	}
+
+	stateFile, args := splitStateFlag(args)
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
 		fmt.Printf("%s URL is required for add action\n", qc.Colorize("❌ Error:", qc.ColorRed))
 		os.Exit(1)
 	}
-
 	url := args[0]
-	stateFile := getStateFile(args[1:])
-	method := getStringFlag(args[1:], "--method", "GET")
-	headers := getStringSliceFlag(args[1:], "--header")
-	threshold := getIntFlag(args[1:], "--threshold", 30)
-	checkStrategy := getStringFlag(args[1:], "--check-strategy", "http")
-	alertStrategy := getStringFlag(args[1:], "--alert-strategy", "console")
+	policy := getStringFlag(args[1:], "--on-duplicate", "")
+
+	handleAddTarget(stateFile, url, parseAddOptions(args[1:]), policy)
+}
 
-	handleAddTarget(stateFile, url, method, headers, threshold, checkStrategy, alertStrategy)
+// addOptions are the target flags of the add action
+type addOptions struct {
+	method        string
+	headers       []string
+	threshold     int
+	checkStrategy string
+	alertStrategy string
+	given         func(flag string) bool // whether the flag was passed, for --on-duplicate merge
+}
+
+// parseAddOptions reads the add action's target flags
+func parseAddOptions(flags []string) addOptions {
+	return addOptions{
+		method:        getStringFlag(flags, "--method", "GET"),
+		headers:       getStringSliceFlag(flags, "--header"),
+		threshold:     getIntFlag(flags, "--threshold", 30),
+		checkStrategy: getStringFlag(flags, "--check-strategy", "http"),
+		alertStrategy: getStringFlag(flags, "--alert-strategy", "console"),
+		given:         func(flag string) bool { return slices.Contains(flags, flag) },
+	}
+}
+
+// newTarget builds the target added for url, with the add action's defaults
+func (o addOptions) newTarget(url string) Target {
+	target := Target{
+		Name:        fmt.Sprintf("Target-%s", url),
+		URL:         url,
+		Method:      o.method,
+		Headers:     parseHeaders(o.headers),
+		Threshold:   o.threshold,
+		StatusCodes: []string{"*"}, // Default to accept all status codes
+		SizeAlerts: SizeAlertConfig{
+			Enabled:     true,
+			HistorySize: 100,
+			Threshold:   0.5, // 50% change threshold
+		},
+		CheckStrategy: o.checkStrategy,
+		// Prefer new multi-alerts field, but preserve legacy single field via applyDefaultsAfterClean
+		Alerts: []string{o.alertStrategy},
+	}
+
+	// Preserve user-entered values as-is; apply runtime defaults only when missing
+	applyDefaultsAfterClean(&target)
+	return target
+}
+
+// mergeUpdate builds the update merged into an existing target for url: only the flags
+// that were passed
+func (o addOptions) mergeUpdate(url string) Target {
+	update := Target{URL: url, Headers: parseHeaders(o.headers)}
+	if o.given("--method") {
+		update.Method = o.method
+	}
+	if o.given("--threshold") {
+		update.Threshold = o.threshold
+	}
+	if o.given("--check-strategy") {
+		update.CheckStrategy = o.checkStrategy
+	}
+	if o.given("--alert-strategy") {
+		update.Alerts = []string{o.alertStrategy}
+	}
+	return update
 }
 
 // handleRemoveCommand handles the rm action
 func handleRemoveCommand(args []string) {
+	if slices.Contains(args, "--all") || slices.Contains(args, "--match") {
+		match, err := removeTargetsMatch(args)
+		if err != nil {
+			fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		handleRemoveTargets(getStateFile(args), match, slices.Contains(args, "--yes"))
+		return
+	}
+	stateFile, args := splitStateFlag(args)
 	if len(args) == 0 {
 		fmt.Printf("%s URL is required for rm action\n", qc.Colorize("❌ Error:", qc.ColorRed))
 		os.Exit(1)
 	}
 
 	url := args[0]
-	stateFile := getStateFile(args[1:])
 	handleRemoveTarget(stateFile, url)
 }
 
+// handlePauseCommand handles the pause and resume actions
+func handlePauseCommand(args []string, paused bool) {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+	stateFile, args := splitStateFlag(args)
+	if len(args) == 0 {
+		fmt.Printf("%s URL is required for %s action\n", qc.Colorize("❌ Error:", qc.ColorRed), action)
+		os.Exit(1)
+	}
+
+	url := args[0]
+	stateManager := NewStateManager(stateFile)
+	if err := stateManager.Load(); err != nil {
+		log.Fatal(err)
+	}
+	if err := stateManager.SetTargetPaused(url, paused); err != nil {
+		log.Fatal(err)
+	}
+
+	if paused {
+		fmt.Printf("%s Paused target: %s\n", qc.Colorize("⏸️ Success:", qc.ColorGreen), url)
+	} else {
+		fmt.Printf("%s Resumed target: %s\n", qc.Colorize("▶️ Success:", qc.ColorGreen), url)
+	}
+}
+
 // handleListCommand handles the list action
 func handleListCommand(args []string) {
+	if slices.Contains(args, "--watch") {
+		handleListWatch(args)
+		return
+	}
 	stateFile := getStateFile(args)
 	handleListTargets(stateFile)
 }
 
 // handleConfigCommand handles the config action
 func handleConfigCommand(args []string) {
+	if slices.Contains(args, "--effective") {
+		handleEffectiveConfig(getStateFile(args))
+		return
+	}
+	if slices.Contains(args, "--canonical") {
+		handleCanonicalConfig(getStateFile(args), getStringFlag(args, "--format", "yaml"))
+		return
+	}
 	if len(args) == 0 {
 		fmt.Printf("%s Configuration file is required for config action\n", qc.Colorize("❌ Error:", qc.ColorRed))
 		os.Exit(1)
@@ -172,6 +364,126 @@ func handleConfigCommand(args []string) {
 	handleConfigMode(configFile, webhookPort, webhookPath)
 }
 
+// handleEffectiveConfig prints the resolved configuration from the state file as JSON,
+// matching GET /api/config/effective
+func handleEffectiveConfig(stateFile string) {
+	sm := NewStateManager(stateFile)
+	if err := sm.Load(); err != nil {
+		fmt.Printf("%s Failed to load state: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	data, err := json.MarshalIndent(sm.GetEffectiveConfig(), "", "  ")
+	if err != nil {
+		fmt.Printf("%s Failed to encode configuration: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// handleCanonicalConfig prints the stored configuration in a stable, sorted form
+// (yaml or json) so that changes diff cleanly in version control
+func handleCanonicalConfig(stateFile, format string) {
+	sm := NewStateManager(stateFile)
+	if err := sm.Load(); err != nil {
+		fmt.Printf("%s Failed to load state: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	data, err := yaml.Marshal(sm.GetCanonicalConfig())
+	if err != nil {
+		fmt.Printf("%s Failed to encode configuration: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	switch format {
+	case "yaml":
+		fmt.Print(string(data))
+	case "json":
+		// Round-trip through YAML so JSON uses the same snake_case keys, sorted
+		var generic any
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			fmt.Printf("%s Failed to encode configuration: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		jsonData, err := json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			fmt.Printf("%s Failed to encode configuration: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonData))
+	default:
+		fmt.Printf("%s Unknown format: %s (use yaml or json)\n", qc.Colorize("❌ Error:", qc.ColorRed), format)
+		os.Exit(1)
+	}
+}
+
+// handleExportCommand writes the configuration in the state file to a bundle; the
+// format follows the file extension (.json or YAML) unless --format is given
+func handleExportCommand(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Printf("%s Bundle file is required for export action\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	bundleFile := args[0]
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(bundleFile), ".json") {
+		format = "json"
+	}
+	format = getStringFlag(args[1:], "--format", format)
+
+	sm := NewStateManager(getStateFile(args[1:]))
+	if err := sm.Load(); err != nil {
+		fmt.Printf("%s Failed to load state: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	data, err := sm.ExportBundle(format)
+	if err != nil {
+		fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	// The bundle holds credentials, so keep it private to the user
+	if err := os.WriteFile(bundleFile, data, 0600); err != nil {
+		fmt.Printf("%s Failed to write bundle: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Exported %d targets, %d alerts and %d hooks to %s\n", qc.Colorize("✅ Success:", qc.ColorGreen),
+		len(sm.state.Targets), len(sm.state.Alerts), len(sm.state.Hooks), bundleFile)
+}
+
+// handleImportCommand validates a bundle written by export and replaces the state
+// file's configuration with it, or merges it in with --merge
+func handleImportCommand(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Printf("%s Bundle file is required for import action\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Printf("%s Failed to read bundle: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	merge := slices.Contains(args[1:], "--merge")
+
+	stateFile := getStateFile(args[1:])
+	sm := NewStateManager(stateFile)
+	if err := sm.Load(); err != nil {
+		fmt.Printf("%s Failed to load state: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	bundle, err := sm.ImportBundle(data, merge)
+	if err != nil {
+		fmt.Printf("%s Import failed, %s left unchanged: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), stateFile, err)
+		os.Exit(1)
+	}
+	mode := "Replaced the configuration with"
+	if merge {
+		mode = "Merged"
+	}
+	fmt.Printf("%s %s %d targets, %d alerts and %d hooks from %s into %s\n", qc.Colorize("✅ Success:", qc.ColorGreen),
+		mode, len(bundle.Targets), len(bundle.Alerts), len(bundle.Hooks), args[0], stateFile)
+}
+
 // handleServerCommand handles the server action
 func handleServerCommand(args []string) {
 	stateFile := getStateFile(args)
@@ -183,6 +495,21 @@ func getStateFile(args []string) string {
 	return getStringFlag(args, "--state", "watch-state.yml")
 }
 
+// splitStateFlag returns the state file and args without the --state pair, so a leading
+// --state does not take the place of a positional argument
+func splitStateFlag(args []string) (string, []string) {
+	stateFile := getStateFile(args)
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--state" && i+1 < len(args) {
+			i++
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	return stateFile, rest
+}
+
 // getStringFlag extracts a string flag from arguments
 func getStringFlag(args []string, flag, defaultValue string) string {
 	for i, arg := range args {
@@ -260,9 +587,16 @@ func handleConfigMode(configFile string, webhookPort int, webhookPath string) {
 	// Wait for context cancellation
 	<-ctx.Done()
 
-	// Stop webhook server if running
+	// Stop the engine and webhook server within the default shutdown budget
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer stopCancel()
+	if err := engine.Stop(stopCtx); err != nil {
+		log.Printf("Error stopping targeting engine: %v", err)
+	}
 	if webhookServer != nil {
-		webhookServer.Stop(context.Background())
+		if err := webhookServer.Stop(stopCtx); err != nil {
+			log.Printf("Error stopping webhook server: %v", err)
+		}
 	}
 
 	fmt.Println("Target stopped.")
@@ -279,7 +613,7 @@ func loadConfiguration(configFile, url, method string, headers []string, thresho
 			return nil, fmt.Errorf("failed to read config file: %v", err)
 		}
 
-		config, err = LoadYAMLConfig(data)
+		config, err = LoadYAMLConfigFrom(data, filepath.Dir(configFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse YAML config file: %v", err)
 		}
@@ -340,6 +674,10 @@ type TargetFields struct {
 	CheckStrategy bool
 	Alerts        bool
 	Ports         bool
+	// Optional behaviour flags whose zero value is meaningful
+	InsecureSkipVerify bool
+	GRPCTLS            bool
+	Paused             bool
 }
 
 // applyDefaultsAfterClean applies default values after cleaning
@@ -350,7 +688,7 @@ func applyDefaultsAfterClean(target *Target) {
 	if target.Headers == nil {
 		target.Headers = make(map[string]string)
 	}
-	if target.Threshold == 0 {
+	if target.Threshold == 0 && target.DownAfterFailures == 0 {
 		target.Threshold = 30
 	}
 	if len(target.StatusCodes) == 0 {
@@ -410,7 +748,7 @@ func printTargetStatus(engine *TargetEngine) {
 		// Show additional details if available
 		if state.LastCheck != nil {
 			fmt.Printf("     Last check: %s (Status: %d, Time: %v)\n",
-				state.LastCheck.Timestamp.Format("15:04:05"),
+				formatClock(state.LastCheck.Timestamp),
 				state.LastCheck.StatusCode,
 				state.LastCheck.ResponseTime,
 			)
@@ -476,8 +814,9 @@ func handleServerMode(stateFile string) {
 	fmt.Println("Server stopped.")
 }
 
-// handleAddTarget adds a target to the state file
-func handleAddTarget(stateFile, url, method string, headers []string, threshold int, checkStrategy, alertStrategy string) {
+// handleAddTarget adds a target to the state file; given reports which flags were passed,
+// so merging into an existing target only changes those
+func handleAddTarget(stateFile, url string, opts addOptions, policy string) {
 	stateManager := NewStateManager(stateFile)
 
 	// Load existing state
@@ -485,29 +824,33 @@ func handleAddTarget(stateFile, url, method string, headers []string, threshold
 		log.Printf("Warning: Could not load existing state: %v", err)
 	}
 
-	// Create target
-	target := Target{
-		Name:        fmt.Sprintf("Target-%s", url),
-		URL:         url,
-		Method:      method,
-		Headers:     parseHeaders(headers),
-		Threshold:   threshold,
-		StatusCodes: []string{"*"}, // Default to accept all status codes
-		SizeAlerts: SizeAlertConfig{
-			Enabled:     true,
-			HistorySize: 100,
-			Threshold:   0.5, // 50% change threshold
-		},
-		CheckStrategy: checkStrategy,
-		// Prefer new multi-alerts field, but preserve legacy single field via applyDefaultsAfterClean
-		Alerts: []string{alertStrategy},
+	policy = cmp.Or(policy, stateManager.GetSettings().DuplicateTargets, DuplicateTargetsError)
+	if _, exists := stateManager.GetTarget(url); exists && policy == DuplicateTargetsMerge {
+		merged, err := stateManager.AddTargetWithPolicy(opts.mergeUpdate(url), policy)
+		if err != nil {
+			fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s Merged into existing target: %s\n", qc.Colorize("✅ Success:", qc.ColorGreen), merged.Name)
+		fmt.Printf("  URL: %s\n", merged.URL)
+		fmt.Printf("  Method: %s\n", merged.Method)
+		fmt.Printf("  Threshold: %d seconds\n", merged.Threshold)
+		fmt.Printf("  Check Strategy: %s\n", merged.CheckStrategy)
+		fmt.Printf("  Alerts: %s\n", strings.Join(merged.Alerts, ", "))
+		return
 	}
 
-	// Preserve user-entered values as-is; apply runtime defaults only when missing
-	applyDefaultsAfterClean(&target)
+	// Create target
+	target := opts.newTarget(url)
 
 	// Add target
-	if err := stateManager.AddTarget(target); err != nil {
+	if _, err := stateManager.AddTargetWithPolicy(target, policy); err != nil {
+		var duplicate *DuplicateTargetError
+		if errors.As(err, &duplicate) {
+			fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			fmt.Println("  Use --on-duplicate overwrite to replace it or --on-duplicate merge to update it")
+			os.Exit(1)
+		}
 		log.Fatal(err)
 	}
 
@@ -536,6 +879,60 @@ func handleRemoveTarget(stateFile, url string) {
 	fmt.Printf("%s Removed target: %s\n", qc.Colorize("🗑️ Success:", qc.ColorGreen), url)
 }
 
+// removeTargetsMatch returns the --match text of rm, or "" for --all. An empty match
+// removes every target, so it is only accepted together with --all.
+func removeTargetsMatch(args []string) (string, error) {
+	match := ""
+	if i := slices.Index(args, "--match"); i >= 0 {
+		if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+			return "", fmt.Errorf("--match requires the text to match")
+		}
+		match = args[i+1]
+	}
+	if strings.TrimSpace(match) == "" && !slices.Contains(args, "--all") {
+		return "", fmt.Errorf("--match text is empty; use --all to remove every target")
+	}
+	return match, nil
+}
+
+// handleRemoveTargets removes every target whose URL or name contains match ("" for all).
+// Without confirm it only lists the targets that would be removed.
+func handleRemoveTargets(stateFile, match string, confirm bool) {
+	stateManager := NewStateManager(stateFile)
+	if err := stateManager.Load(); err != nil {
+		log.Fatal(err)
+	}
+
+	var matched []string
+	for url, target := range stateManager.ListTargets() {
+		if targetMatches(target, match) {
+			matched = append(matched, fmt.Sprintf("%s (%s)", url, target.Name))
+		}
+	}
+	if len(matched) == 0 {
+		fmt.Printf("%s No targets match\n", qc.Colorize("ℹ️ Info:", qc.ColorYellow))
+		return
+	}
+	if !confirm {
+		slices.Sort(matched)
+		fmt.Printf("%s This would remove %d target(s):\n", qc.Colorize("⚠️ Warning:", qc.ColorYellow), len(matched))
+		for _, target := range matched {
+			fmt.Printf("  %s\n", target)
+		}
+		fmt.Println("  Re-run with --yes to remove them")
+		os.Exit(1)
+	}
+
+	removed, err := stateManager.RemoveTargets(match)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, url := range removed {
+		fmt.Printf("  %s\n", url)
+	}
+	fmt.Printf("%s Removed %d target(s)\n", qc.Colorize("🗑️ Success:", qc.ColorGreen), len(removed))
+}
+
 // handleListTargets lists all targets in the state file
 func handleListTargets(stateFile string) {
 	stateManager := NewStateManager(stateFile)
@@ -570,8 +967,11 @@ func handleListTargets(stateFile string) {
 		if alerts == "" && target.AlertStrategy != "" {
 			alerts = target.AlertStrategy
 		}
-		fmt.Printf("     Method: %s, Threshold: %ds, Check: %s, Alert: %s\n",
-			target.Method, target.Threshold, target.CheckStrategy, alerts)
+		fmt.Printf("     Method: %s, Threshold: %s, Check: %s, Alert: %s\n",
+			target.Method, describeThreshold(target), target.CheckStrategy, alerts)
+		if target.Paused {
+			fmt.Println(qc.Colorize("     ⏸️ Paused", qc.ColorYellow))
+		}
 		i++
 	}
 }
@@ -692,6 +1092,7 @@ func handleValidateCommand(args []string) {
 	stateFile := "watch-state.yml"
 	configFile := ""
 	verbose := false
+	dryRun := false
 
 	// Parse flags
 	for i := 0; i < len(args); i++ {
@@ -714,16 +1115,644 @@ func handleValidateCommand(args []string) {
 			}
 		case "--verbose", "-v":
 			verbose = true
+		case "--dry-run":
+			dryRun = true
 		default:
 			fmt.Printf("%s Unknown option: %s\n", qc.Colorize("❌ Error:", qc.ColorRed), args[i])
 			os.Exit(1)
 		}
 	}
 
-	// Validate configuration
+	// Validate configuration; both validators exit non-zero on errors
 	if configFile != "" {
 		validateConfigFile(configFile, verbose)
 	} else {
 		validateStateFile(stateFile, verbose)
 	}
+	if !dryRun {
+		return
+	}
+
+	// Dry run: check every target once with the same check strategies the server uses
+	var targets []Target
+	var settings ServerSettings
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			fmt.Printf("%s Failed to read config file: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		config, err := LoadYAMLConfigFrom(data, filepath.Dir(configFile))
+		if err != nil {
+			fmt.Printf("%s Failed to load config file: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		targets = config.Targets
+	} else {
+		stateManager := NewStateManager(stateFile)
+		if err := stateManager.Load(); err != nil {
+			fmt.Printf("%s Failed to load state file: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+			os.Exit(1)
+		}
+		targets = stateManager.GetTargetConfig().Targets
+		settings = stateManager.GetSettings()
+	}
+	if len(targets) == 0 {
+		fmt.Printf("%s No targets to check\n", qc.Colorize("ℹ️ Info:", qc.ColorYellow))
+		return
+	}
+
+	fmt.Printf("%s Checking %d targets once (no alerts are sent)\n", qc.Colorize("🔍 Dry run:", qc.ColorCyan), len(targets))
+	configureProxy(settings)
+	results := runChecksOnce(context.Background(), targets, newCheckStrategies(settings), defaultCheckConcurrency)
+	if failures := printCheckResults(results); failures > 0 {
+		fmt.Printf("%s %d of %d targets failed their live check\n", qc.Colorize("❌ Error:", qc.ColorRed), failures, len(results))
+		os.Exit(1)
+	}
+	fmt.Printf("%s All live checks passed\n", qc.Colorize("✅ Success:", qc.ColorGreen))
+}
+
+// defaultCheckConcurrency caps how many targets the check command probes at once
+const defaultCheckConcurrency = 10
+
+// onceCheckResult is the outcome of a single one-shot target check
+type onceCheckResult struct {
+	Target  Target
+	Result  *CheckResult
+	Skipped bool
+}
+
+// handleCheckCommand runs every configured target once and exits 0 only if
+// no more than --tolerance targets failed; intended as a CI smoke test
+func handleCheckCommand(args []string) {
+	stateFile := "watch-state.yml"
+	concurrency := defaultCheckConcurrency
+	tolerance := 0
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--once":
+			// One-shot is the only mode; accepted for readability in CI scripts
+		case "--state":
+			if i+1 < len(args) {
+				stateFile = args[i+1]
+				i++ // Skip next argument
+			} else {
+				fmt.Printf("%s --state requires a file path\n", qc.Colorize("❌ Error:", qc.ColorRed))
+				os.Exit(1)
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				if n, err := strconv.Atoi(args[i+1]); err == nil && n > 0 {
+					concurrency = n
+				} else {
+					fmt.Printf("%s --concurrency must be a positive integer, got %s\n", qc.Colorize("❌ Error:", qc.ColorRed), args[i+1])
+					os.Exit(1)
+				}
+				i++ // Skip next argument
+			} else {
+				fmt.Printf("%s --concurrency requires a number\n", qc.Colorize("❌ Error:", qc.ColorRed))
+				os.Exit(1)
+			}
+		case "--tolerance":
+			if i+1 < len(args) {
+				if n, err := strconv.Atoi(args[i+1]); err == nil && n >= 0 {
+					tolerance = n
+				} else {
+					fmt.Printf("%s --tolerance must be a non-negative integer, got %s\n", qc.Colorize("❌ Error:", qc.ColorRed), args[i+1])
+					os.Exit(1)
+				}
+				i++ // Skip next argument
+			} else {
+				fmt.Printf("%s --tolerance requires a number\n", qc.Colorize("❌ Error:", qc.ColorRed))
+				os.Exit(1)
+			}
+		default:
+			fmt.Printf("%s Unknown option: %s\n", qc.Colorize("❌ Error:", qc.ColorRed), args[i])
+			os.Exit(1)
+		}
+	}
+
+	stateManager := NewStateManager(stateFile)
+	if err := stateManager.Load(); err != nil {
+		fmt.Printf("%s Failed to load state file: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	targets := stateManager.GetTargetConfig().Targets
+	if len(targets) == 0 {
+		fmt.Printf("%s No targets configured\n", qc.Colorize("ℹ️ Info:", qc.ColorYellow))
+		return
+	}
+
+	configureProxy(stateManager.GetSettings())
+	configureTimezone(stateManager.GetSettings())
+	results := runChecksOnce(context.Background(), targets, newCheckStrategies(stateManager.GetSettings()), concurrency)
+	failures := printCheckResults(results)
+
+	if failures > tolerance {
+		fmt.Printf("%s %d of %d targets failed (tolerance %d)\n", qc.Colorize("❌ Error:", qc.ColorRed), failures, len(results), tolerance)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %d of %d targets failed (tolerance %d)\n", qc.Colorize("✅ Success:", qc.ColorGreen), failures, len(results), tolerance)
+}
+
+// handleCheckURLCommand checks url --count times, --interval apart, and exits non-zero
+// if any check failed
+func handleCheckURLCommand(url string, args []string) {
+	count := getIntFlag(args, "--count", 1)
+	if count < 1 {
+		fmt.Printf("%s --count must be a positive integer\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	intervalFlag := getStringFlag(args, "--interval", "1s")
+	interval, err := time.ParseDuration(intervalFlag)
+	if seconds, convErr := strconv.Atoi(intervalFlag); convErr == nil {
+		interval, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil || interval < 0 {
+		fmt.Printf("%s --interval must be a duration such as 2s or 500ms, got %s\n", qc.Colorize("❌ Error:", qc.ColorRed), intervalFlag)
+		os.Exit(1)
+	}
+
+	target := Target{
+		Name:        url,
+		URL:         url,
+		Method:      getStringFlag(args, "--method", "GET"),
+		Headers:     parseHeaders(getStringSliceFlag(args, "--header")),
+		StatusCodes: []string{"*"},
+	}
+	if codes := getStringFlag(args, "--status-codes", ""); codes != "" {
+		target.StatusCodes = strings.Split(codes, ",")
+	}
+	for _, pattern := range target.StatusCodes {
+		if _, err := matchStatusCodePattern(200, pattern); err != nil {
+			fmt.Printf("%s Invalid --status-codes entry %q: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), pattern, err)
+			os.Exit(1)
+		}
+	}
+
+	// Honor the state file's ca_bundle_file, default_headers and proxy_url when there is one
+	var settings ServerSettings
+	if stateFile := getStateFile(args); stateFile != stdioStatePath {
+		if _, err := os.Stat(stateFile); err == nil {
+			stateManager := NewStateManager(stateFile)
+			if err := stateManager.Load(); err != nil {
+				log.Printf("Warning: Could not load existing state: %v", err)
+			}
+			settings = stateManager.GetSettings()
+		}
+	}
+	configureProxy(settings)
+	configureTimezone(settings)
+	strategy := newCheckStrategies(settings)["http"]
+
+	results := repeatCheck(context.Background(), strategy, &target, count, interval, func(n int, result *CheckResult) {
+		status, color := "PASS", qc.ColorGreen
+		if !result.Success {
+			status, color = "FAIL", qc.ColorRed
+		}
+		code := "-"
+		if result.StatusCode > 0 {
+			code = strconv.Itoa(result.StatusCode)
+		}
+		fmt.Printf("%s  %d/%d  %-4s  %-10s  %s\n", qc.Colorize(fmt.Sprintf("%-6s", status), color), n, count, code, result.ResponseTime.Round(time.Millisecond), result.Error)
+	})
+
+	failures, summary := summarizeChecks(results)
+	if failures > 0 {
+		fmt.Printf("%s %s\n", qc.Colorize("❌ Error:", qc.ColorRed), summary)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %s\n", qc.Colorize("✅ Success:", qc.ColorGreen), summary)
+}
+
+// repeatCheck runs count checks of target, interval apart, passing each result to report
+func repeatCheck(ctx context.Context, strategy CheckStrategy, target *Target, count int, interval time.Duration, report func(n int, result *CheckResult)) []*CheckResult {
+	results := make([]*CheckResult, 0, count)
+	for n := 1; n <= count; n++ {
+		if n > 1 {
+			select {
+			case <-ctx.Done():
+				return results
+			case <-time.After(interval):
+			}
+		}
+		result, err := strategy.Check(ctx, target)
+		if err != nil {
+			result = &CheckResult{Success: false, Error: err.Error(), Timestamp: time.Now()}
+		}
+		results = append(results, result)
+		report(n, result)
+	}
+	return results
+}
+
+// summarizeChecks counts failed checks and describes the run with min/avg/max response times
+func summarizeChecks(results []*CheckResult) (int, string) {
+	failures := 0
+	var total, fastest, slowest time.Duration
+	for i, result := range results {
+		if !result.Success {
+			failures++
+		}
+		total += result.ResponseTime
+		if i == 0 || result.ResponseTime < fastest {
+			fastest = result.ResponseTime
+		}
+		slowest = max(slowest, result.ResponseTime)
+	}
+	var average time.Duration
+	if len(results) > 0 {
+		average = total / time.Duration(len(results))
+	}
+	return failures, fmt.Sprintf("%d of %d checks failed (response time min %s, avg %s, max %s)",
+		failures, len(results), fastest.Round(time.Millisecond), average.Round(time.Millisecond), slowest.Round(time.Millisecond))
+}
+
+// runChecksOnce checks each target once, at most concurrency at a time, preserving target order
+func runChecksOnce(ctx context.Context, targets []Target, strategies map[string]CheckStrategy, concurrency int) []onceCheckResult {
+	results := make([]onceCheckResult, len(targets))
+	limiter := NewCheckLimiter(concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		results[i].Target = target
+		// Webhook targets are triggered externally and have nothing to probe
+		if target.CheckStrategy == "webhook" {
+			results[i].Skipped = true
+			continue
+		}
+		strategy, exists := strategies[target.CheckStrategy]
+		if !exists {
+			strategy = strategies["http"]
+		}
+
+		wg.Add(1)
+		go func(i int, target Target, strategy CheckStrategy) {
+			defer wg.Done()
+			if err := limiter.Acquire(ctx); err != nil {
+				results[i].Result = &CheckResult{Success: false, Error: err.Error(), Timestamp: time.Now()}
+				return
+			}
+			defer limiter.Release()
+
+			check := func(target *Target) *CheckResult {
+				result, err := strategy.Check(ctx, target)
+				if err != nil {
+					result = &CheckResult{Success: false, Error: err.Error(), Timestamp: time.Now()}
+				}
+				return result
+			}
+			if len(target.URLs) > 0 {
+				results[i].Result = checkBackends(&target, limiter, check)
+			} else {
+				results[i].Result = check(&target)
+			}
+		}(i, target, strategy)
+	}
+	wg.Wait()
+	return results
+}
+
+// printCheckResults prints a result table and returns the number of failed targets
+func printCheckResults(results []onceCheckResult) int {
+	nameWidth := len("TARGET")
+	for _, r := range results {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(r.Target.Name))
+	}
+
+	failures := 0
+	fmt.Printf("%-6s  %-*s  %-6s  %-10s  %s\n", "STATUS", nameWidth, "TARGET", "CODE", "TIME", "ERROR")
+	for _, r := range results {
+		status, code, elapsed, errMsg := "SKIP", "-", "-", ""
+		color := qc.ColorYellow
+		if !r.Skipped {
+			status, color = "PASS", qc.ColorGreen
+			if !r.Result.Success {
+				status, color = "FAIL", qc.ColorRed
+				failures++
+			}
+			if r.Result.StatusCode > 0 {
+				code = strconv.Itoa(r.Result.StatusCode)
+			}
+			elapsed = r.Result.ResponseTime.Round(time.Millisecond).String()
+			errMsg = r.Result.Error
+		}
+		padding := strings.Repeat(" ", nameWidth-utf8.RuneCountInString(r.Target.Name))
+		fmt.Printf("%s  %s%s  %-6s  %-10s  %s\n", qc.Colorize(fmt.Sprintf("%-6s", status), color), r.Target.Name, padding, code, elapsed, errMsg)
+	}
+	return failures
+}
+
+// handleTestNotifierCommand builds the named alert strategy from the state file and
+// sends it a synthetic DOWN alert followed by an all-clear, reporting any error
+func handleTestNotifierCommand(name string, args []string) {
+	stateManager := NewStateManager(getStateFile(args))
+	if err := stateManager.Load(); err != nil {
+		fmt.Printf("%s Failed to load state file: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	notifier, configured := stateManager.GetAlerts()[name]
+	if configured && !notifier.Enabled {
+		fmt.Printf("%s Alert '%s' is disabled; enable it before testing\n", qc.Colorize("❌ Error:", qc.ColorRed), name)
+		os.Exit(1)
+	}
+
+	configureProxy(stateManager.GetSettings())
+	configureTimezone(stateManager.GetSettings())
+	engine := NewTargetEngine(&TargetConfig{}, stateManager)
+	strategy, exists := engine.alertStrategies[name]
+	if !exists {
+		if configured {
+			fmt.Printf("%s Alert '%s' (type %s) could not be constructed; run 'validate' to check its settings\n", qc.Colorize("❌ Error:", qc.ColorRed), name, notifier.Type)
+		} else {
+			fmt.Printf("%s Alert '%s' not found\n", qc.Colorize("❌ Error:", qc.ColorRed), name)
+		}
+		os.Exit(1)
+	}
+
+	if err := sendTestAlert(context.Background(), strategy); err != nil {
+		fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Sample alert and all-clear sent through '%s'\n", qc.Colorize("✅ Success:", qc.ColorGreen), name)
+}
+
+// sendTestAlert sends a synthetic DOWN alert and the matching all-clear through strategy
+func sendTestAlert(ctx context.Context, strategy AlertStrategy) error {
+	target := &Target{
+		Name:          "Quick Watch Test Alert",
+		URL:           "https://example.com/quick-watch-test",
+		Method:        "GET",
+		Threshold:     30,
+		CheckStrategy: "http",
+	}
+	now := time.Now()
+	down := &CheckResult{
+		Success:      false,
+		StatusCode:   http.StatusServiceUnavailable,
+		ResponseTime: 1500 * time.Millisecond,
+		Error:        "Sample failure sent by 'quick_watch test'; no real target is down",
+		Timestamp:    now,
+	}
+	if err := strategy.SendAlert(ctx, target, down); err != nil {
+		return fmt.Errorf("sample alert via %s failed: %w", strategy.Name(), err)
+	}
+	up := &CheckResult{
+		Success:      true,
+		StatusCode:   http.StatusOK,
+		ResponseTime: 120 * time.Millisecond,
+		Timestamp:    now.Add(time.Second),
+	}
+	if err := strategy.SendAllClear(ctx, target, up); err != nil {
+		return fmt.Errorf("sample all-clear via %s failed: %w", strategy.Name(), err)
+	}
+	return nil
+}
+
+// handlePagingCommand toggles critical-only paging on a running server via /api/paging
+func handlePagingCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Printf("%s paging requires a mode: critical-only, all, or status\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	mode := args[0]
+	stateFile := getStateFile(args[1:])
+	serverURL := getStringFlag(args[1:], "--server", "")
+	stateManager := NewStateManager(stateFile)
+	if err := stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load existing state: %v", err)
+	}
+	settings := stateManager.GetSettings()
+	serverURL = runningServerURL(settings, serverURL)
+	endpoint := serverURL + "/api/paging"
+
+	var req *http.Request
+	var err error
+	switch mode {
+	case "critical-only", "all":
+		body := fmt.Sprintf(`{"critical_only": %t}`, mode == "critical-only")
+		req, err = http.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	case "status":
+		req, err = http.NewRequest(http.MethodGet, endpoint, nil)
+	default:
+		fmt.Printf("%s Unknown paging mode: %s (use critical-only, all, or status)\n", qc.Colorize("❌ Error:", qc.ColorRed), mode)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("%s Invalid server address %s: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), serverURL, err)
+		os.Exit(1)
+	}
+	setAPIAuthHeader(req, settings)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Printf("%s Failed to reach server at %s: %v\n", qc.Colorize("❌ Error:", qc.ColorRed), endpoint, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		PagingMode string `json:"paging_mode"`
+	}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&result) != nil {
+		fmt.Printf("%s Server returned status %d\n", qc.Colorize("❌ Error:", qc.ColorRed), resp.StatusCode)
+		os.Exit(1)
+	}
+	fmt.Printf("%s Paging mode: %s\n", qc.Colorize("📟 Info:", qc.ColorCyan), result.PagingMode)
+}
+
+// runningServerURL returns the base URL of the running server: the --server flag
+// value when given, otherwise the state file's server_address or localhost
+func runningServerURL(settings ServerSettings, serverFlag string) string {
+	serverURL := serverFlag
+	if serverURL == "" {
+		serverURL = settings.ServerAddress
+		if serverURL == "" {
+			serverURL = fmt.Sprintf("http://localhost:%d", settings.WebhookPort)
+		}
+	}
+	return strings.TrimRight(serverURL, "/")
+}
+
+// setAPIAuthHeader sends the state file's api_auth credentials so CLI requests work
+// against a protected server
+func setAPIAuthHeader(req *http.Request, settings ServerSettings) {
+	if settings.APIAuth.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+settings.APIAuth.BearerToken)
+	} else if settings.APIAuth.Username != "" {
+		req.SetBasicAuth(settings.APIAuth.Username, settings.APIAuth.Password)
+	}
+}
+
+// handleHistoryCommand prints a target's most recent checks, fetched from the
+// running server's /api/history endpoint
+func handleHistoryCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Printf("%s URL is required for history action\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	url := args[0]
+	flags := args[1:]
+	stateManager := NewStateManager(getStateFile(flags))
+	if err := stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load existing state: %v", err)
+	}
+	target, exists := stateManager.GetTarget(url)
+	if !exists {
+		fmt.Printf("%s Target not found: %s\n", qc.Colorize("❌ Error:", qc.ColorRed), url)
+		os.Exit(1)
+	}
+	settings := stateManager.GetSettings()
+	endpoint := runningServerURL(settings, getStringFlag(flags, "--server", "")) + "/api/history/" + ToURLSafe(target.Name)
+
+	history, err := fetchTargetHistory(endpoint, settings)
+	if err != nil {
+		// Check history lives in the server's memory, so there is nothing to read without it
+		fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+	if limit := getIntFlag(flags, "--limit", 20); limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	if slices.Contains(flags, "--json") {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(history); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(history) == 0 {
+		fmt.Printf("%s No checks recorded yet for %s\n", qc.Colorize("ℹ️ Info:", qc.ColorYellow), target.Name)
+		return
+	}
+	configureTimezone(settings)
+	fmt.Printf("%s Last %d check(s) for %s (%s):\n", qc.Colorize("📋 Info:", qc.ColorBlue), len(history), target.Name, target.URL)
+	writeHistoryTable(os.Stdout, history)
+}
+
+// fetchTargetHistory reads a target's check history, oldest first, from /api/history
+func fetchTargetHistory(endpoint string, settings ServerSettings) ([]CheckHistoryEntry, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server address: %v", err)
+	}
+	setAPIAuthHeader(req, settings)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach server at %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d for %s", resp.StatusCode, endpoint)
+	}
+
+	var result struct {
+		History []CheckHistoryEntry `json:"history"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode history: %v", err)
+	}
+	return result.History, nil
+}
+
+// handleIncidentsCommand prints incidents from the log kept beside the state file, so it
+// works whether or not the server is running
+func handleIncidentsCommand(args []string) {
+	path := incidentLogPath(getStateFile(args))
+	if path == "" {
+		fmt.Printf("%s incidents are not recorded when state is piped through stdin\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	incidents, err := LoadIncidents(path)
+	if err != nil {
+		fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		os.Exit(1)
+	}
+
+	status := ""
+	if slices.Contains(args, "--open") {
+		status = "open"
+	} else if slices.Contains(args, "--resolved") {
+		status = "resolved"
+	}
+	incidents = filterIncidents(incidents, getStringFlag(args, "--target", ""), status, getIntFlag(args, "--limit", 20), time.Now())
+
+	if slices.Contains(args, "--json") {
+		if incidents == nil {
+			incidents = []Incident{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(incidents); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(incidents) == 0 {
+		fmt.Printf("%s No incidents recorded in %s\n", qc.Colorize("ℹ️ Info:", qc.ColorYellow), path)
+		return
+	}
+	stateManager := NewStateManager(getStateFile(args))
+	if err := stateManager.Load(); err == nil {
+		configureTimezone(stateManager.GetSettings())
+	}
+	writeIncidentTable(os.Stdout, incidents)
+}
+
+// writeIncidentTable prints one row per incident: id, target, start, end, duration and acknowledgement
+func writeIncidentTable(w io.Writer, incidents []Incident) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTARGET\tSTARTED\tRESOLVED\tDURATION\tACKNOWLEDGED")
+	for _, incident := range incidents {
+		resolved := "open"
+		if incident.ResolvedAt != nil {
+			resolved = inDisplayZone(*incident.ResolvedAt).Format("2006-01-02 15:04:05")
+		}
+		acknowledged := "no"
+		if incident.Acknowledged {
+			acknowledged = "yes"
+			if incident.AcknowledgedBy != "" {
+				acknowledged = "by " + incident.AcknowledgedBy
+			}
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", incident.ID, incident.Target, inDisplayZone(incident.StartedAt).Format("2006-01-02 15:04:05"), resolved,
+			time.Duration(incident.DurationSeconds)*time.Second, acknowledged)
+	}
+	tw.Flush()
+}
+
+// writeHistoryTable prints one row per check: time, status, HTTP code, response time and error
+func writeHistoryTable(w io.Writer, history []CheckHistoryEntry) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tSTATUS\tCODE\tRESPONSE\tERROR")
+	for _, entry := range history {
+		status := "UP"
+		switch {
+		case !entry.Success:
+			status = "DOWN"
+		case entry.Slow:
+			status = "SLOW"
+		}
+		code := "-"
+		if entry.StatusCode != 0 {
+			code = strconv.Itoa(entry.StatusCode)
+		}
+		errorMessage := entry.ErrorMessage
+		if errorMessage == "" && entry.SuppressedBy != "" {
+			errorMessage = "alert suppressed: " + entry.SuppressedBy
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%dms\t%s\n", inDisplayZone(entry.Timestamp).Format("2006-01-02 15:04:05"), status, code, entry.ResponseTime, errorMessage)
+	}
+	tw.Flush()
 }
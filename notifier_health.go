@@ -0,0 +1,111 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultNotifierFailureThreshold is settings.notifier_failure_threshold when unset
+const defaultNotifierFailureThreshold = 3
+
+// NotifierHealth tracks consecutive send failures of one notifier, reported in /api/status
+type NotifierHealth struct {
+	Name                string     `json:"name"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	Fallback            string     `json:"fallback"` // alert used while unhealthy
+}
+
+// notifierHealthTracker records delivery results per notifier-based alert strategy
+type notifierHealthTracker struct {
+	mutex     sync.Mutex
+	threshold int
+	notifiers map[AlertStrategy]*NotifierHealth
+}
+
+// newNotifierHealthTracker marks notifiers unhealthy after threshold failures in a row (0 = default)
+func newNotifierHealthTracker(threshold int) *notifierHealthTracker {
+	return &notifierHealthTracker{
+		threshold: cmp.Or(threshold, defaultNotifierFailureThreshold),
+		notifiers: make(map[AlertStrategy]*NotifierHealth),
+	}
+}
+
+// track starts tracking the strategy registered for notifier name; fallback "" means console
+func (t *notifierHealthTracker) track(strategy AlertStrategy, name, fallback string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.notifiers[strategy] = &NotifierHealth{Name: name, Healthy: true, Fallback: cmp.Or(fallback, "console")}
+}
+
+// record notes the outcome of one send and returns the notifier's health after it. The
+// second result is true when this send changed whether the notifier is healthy.
+func (t *notifierHealthTracker) record(strategy AlertStrategy, err error, now time.Time) (NotifierHealth, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	health, exists := t.notifiers[strategy]
+	if !exists {
+		return NotifierHealth{Healthy: true}, false
+	}
+	wasHealthy := health.Healthy
+	if err == nil {
+		health.ConsecutiveFailures = 0
+		health.LastSuccess = &now
+		health.Healthy = true
+	} else {
+		health.ConsecutiveFailures++
+		health.LastError = err.Error()
+		health.LastFailure = &now
+		health.Healthy = health.ConsecutiveFailures < t.threshold
+	}
+	return *health, health.Healthy != wasHealthy
+}
+
+// Snapshot returns every tracked notifier's health, sorted by name
+func (t *notifierHealthTracker) Snapshot() []NotifierHealth {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	snapshot := make([]NotifierHealth, 0, len(t.notifiers))
+	for _, health := range t.notifiers {
+		snapshot = append(snapshot, *health)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Name < snapshot[j].Name })
+	return snapshot
+}
+
+// deliver sends one alert through strategy with send, tracking the notifier's health. While
+// the notifier is unhealthy each failed send is repeated through its fallback alert, so a
+// revoked webhook or expired credential does not silently swallow every alert.
+func (e *TargetEngine) deliver(ctx context.Context, target *Target, strategy AlertStrategy, send func(strategy AlertStrategy) error) {
+	err := send(strategy)
+	health, changed := e.notifierHealth.record(strategy, err, time.Now())
+	if err == nil {
+		if changed {
+			logEvent("notifier.recovered", target.Name, "Notifier %s is healthy again", health.Name)
+		}
+		return
+	}
+
+	logEvent("alert.send_failed", target.Name, "Alert for %s via %s failed: %v", target.Name, strategy.Name(), err)
+	if health.Healthy {
+		return
+	}
+	if changed {
+		logEvent("notifier.unhealthy", target.Name, "Notifier %s is unhealthy after %d failed sends in a row; falling back to %s", health.Name, health.ConsecutiveFailures, health.Fallback)
+	}
+	fallback, exists := e.alertStrategies[health.Fallback]
+	if !exists || fallback == strategy {
+		return
+	}
+	err = send(fallback)
+	e.notifierHealth.record(fallback, err, time.Now())
+	if err != nil {
+		logEvent("alert.send_failed", target.Name, "Fallback alert for %s via %s failed: %v", target.Name, fallback.Name(), err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotifierSchedule limits when a notifier sends alerts, from its settings.active_hours or
+// settings.quiet_hours window
+type NotifierSchedule struct {
+	start, end int  // minutes after midnight; end before start wraps past midnight
+	quiet      bool // the window is quiet_hours (never send inside) rather than active_hours
+	alwaysDown bool // settings.always_send_down: DOWN alerts ignore the window
+	location   *time.Location
+}
+
+// parseNotifierSchedule reads a notifier's active_hours or quiet_hours ("22:00-07:00"),
+// timezone and always_send_down settings; it returns nil when neither window is set
+func parseNotifierSchedule(settings map[string]any) (*NotifierSchedule, error) {
+	activeHours, _ := settings["active_hours"].(string)
+	quietHours, _ := settings["quiet_hours"].(string)
+	if activeHours != "" && quietHours != "" {
+		return nil, fmt.Errorf("set either active_hours or quiet_hours, not both")
+	}
+	if activeHours == "" && quietHours == "" {
+		if _, ok := settings["timezone"]; ok {
+			return nil, fmt.Errorf("timezone requires active_hours or quiet_hours")
+		}
+		return nil, nil
+	}
+
+	schedule := &NotifierSchedule{quiet: quietHours != "", location: time.Local}
+	key, window := "active_hours", activeHours
+	if schedule.quiet {
+		key, window = "quiet_hours", quietHours
+	}
+	var err error
+	if schedule.start, schedule.end, err = parseHourRange(window); err != nil {
+		return nil, fmt.Errorf("%s: %v", key, err)
+	}
+	if tz, ok := settings["timezone"].(string); ok && tz != "" {
+		if schedule.location, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %v", tz, err)
+		}
+	}
+	if v, ok := settings["always_send_down"]; ok {
+		if schedule.alwaysDown, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("always_send_down must be true or false")
+		}
+	}
+	return schedule, nil
+}
+
+// parseHourRange parses "HH:MM-HH:MM" into minutes after midnight
+func parseHourRange(window string) (int, int, error) {
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected HH:MM-HH:MM, got %q", window)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected HH:MM-HH:MM, got %q", window)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected HH:MM-HH:MM, got %q", window)
+	}
+	startMinute, endMinute := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	if startMinute == endMinute {
+		return 0, 0, fmt.Errorf("start and end of %q must differ", window)
+	}
+	return startMinute, endMinute, nil
+}
+
+// Allows reports whether an alert for event ("down", "recovery", ...) may be sent at now
+func (s *NotifierSchedule) Allows(event string, now time.Time) bool {
+	if event == "down" && s.alwaysDown {
+		return true
+	}
+	local := now.In(s.location)
+	minute := local.Hour()*60 + local.Minute()
+	inside := s.start <= minute && minute < s.end
+	if s.end < s.start {
+		inside = minute >= s.start || minute < s.end
+	}
+	return inside != s.quiet
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// otlpExportInterval is how often buffered spans and current metrics are pushed
+	otlpExportInterval = 15 * time.Second
+	// otlpMaxBufferedSpans bounds memory if the collector is unreachable
+	otlpMaxBufferedSpans = 2048
+)
+
+// OTLPExporter pushes check spans and target metrics to an OTLP/HTTP collector
+// using the JSON encoding, so no OpenTelemetry SDK dependency is needed
+type OTLPExporter struct {
+	endpoint  string // collector base URL, e.g. http://localhost:4318
+	client    *http.Client
+	startTime time.Time
+	spans     []otlpSpan
+	mutex     sync.Mutex
+}
+
+// NewOTLPExporter creates an exporter for the given collector base URL
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		client:    &http.Client{Transport: newOutboundTransport(), Timeout: 10 * time.Second},
+		startTime: time.Now(),
+	}
+}
+
+// OTLP/JSON wire types (subset of opentelemetry-proto)
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 1 = OK, 2 = ERROR
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"` // 3 = CLIENT
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes   []otlpKeyValue `json:"attributes"`
+	TimeUnixNano string         `json:"timeUnixNano"`
+	AsInt        string         `json:"asInt"`
+}
+
+type otlpHistogramDataPoint struct {
+	StartTimeUnixNano string    `json:"startTimeUnixNano"`
+	TimeUnixNano      string    `json:"timeUnixNano"`
+	Count             string    `json:"count"`
+	Sum               float64   `json:"sum"`
+	BucketCounts      []string  `json:"bucketCounts"`
+	ExplicitBounds    []float64 `json:"explicitBounds"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpHistogram struct {
+	AggregationTemporality int                      `json:"aggregationTemporality"` // 2 = CUMULATIVE
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Unit        string         `json:"unit,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpKeyValue {
+	str := strconv.FormatInt(value, 10)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &str}}
+}
+
+func otlpBool(key string, value bool) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{BoolValue: &value}}
+}
+
+func otlpNanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// otlpRandomID returns n random bytes hex-encoded, as OTLP/JSON expects for trace and span IDs
+func otlpRandomID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return strings.Repeat("0", 2*n)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (o *OTLPExporter) resource() otlpResource {
+	return otlpResource{Attributes: []otlpKeyValue{
+		otlpString("service.name", "quick_watch"),
+		otlpString("service.version", resolveVersion()),
+	}}
+}
+
+// RecordCheck buffers one span describing a completed check
+func (o *OTLPExporter) RecordCheck(target *Target, result *CheckResult) {
+	start := result.Timestamp
+	if start.IsZero() {
+		start = time.Now()
+	}
+	checkStrategy := target.CheckStrategy
+	if checkStrategy == "" {
+		checkStrategy = "http"
+	}
+	span := otlpSpan{
+		TraceID:           otlpRandomID(16),
+		SpanID:            otlpRandomID(8),
+		Name:              "check " + target.Name,
+		Kind:              3,
+		StartTimeUnixNano: otlpNanos(start),
+		EndTimeUnixNano:   otlpNanos(start.Add(result.ResponseTime)),
+		Attributes: []otlpKeyValue{
+			otlpString("quick_watch.target.name", target.Name),
+			otlpString("quick_watch.target.url", target.URL),
+			otlpString("quick_watch.check_strategy", checkStrategy),
+			otlpBool("quick_watch.check.success", result.Success),
+		},
+		Status: otlpStatus{Code: 1},
+	}
+	if result.StatusCode > 0 {
+		span.Attributes = append(span.Attributes, otlpInt("http.response.status_code", int64(result.StatusCode)))
+	}
+	if !result.Success {
+		span.Status = otlpStatus{Code: 2, Message: result.Error}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if len(o.spans) >= otlpMaxBufferedSpans {
+		o.spans = o.spans[1:]
+	}
+	o.spans = append(o.spans, span)
+}
+
+// Run exports on a fixed interval until ctx is cancelled, then performs a final flush
+func (o *OTLPExporter) Run(ctx context.Context, engine *TargetEngine) {
+	ticker := time.NewTicker(otlpExportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			o.export(flushCtx, engine)
+			cancel()
+			return
+		case <-ticker.C:
+			o.export(ctx, engine)
+		}
+	}
+}
+
+// export pushes buffered spans and a metrics snapshot, logging (not failing) on errors
+func (o *OTLPExporter) export(ctx context.Context, engine *TargetEngine) {
+	if err := o.exportTraces(ctx); err != nil {
+		log.Printf("OTLP trace export failed: %v", err)
+	}
+	if err := o.exportMetrics(ctx, engine); err != nil {
+		log.Printf("OTLP metric export failed: %v", err)
+	}
+}
+
+func (o *OTLPExporter) exportTraces(ctx context.Context) error {
+	o.mutex.Lock()
+	spans := o.spans
+	o.spans = nil
+	o.mutex.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	payload := map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": o.resource(),
+			"scopeSpans": []any{map[string]any{
+				"scope": otlpScope{Name: "quick_watch", Version: resolveVersion()},
+				"spans": spans,
+			}},
+		}},
+	}
+	return o.post(ctx, "/v1/traces", payload)
+}
+
+func (o *OTLPExporter) exportMetrics(ctx context.Context, engine *TargetEngine) error {
+	now := otlpNanos(time.Now())
+
+	up := otlpMetric{Name: "quick_watch.target.up", Description: "1 if the target is currently up, 0 if down", Unit: "1"}
+	up.Gauge = &otlpGauge{}
+	for _, state := range engine.GetTargetStatus() {
+		value := "1"
+		if state.IsDown {
+			value = "0"
+		}
+		up.Gauge.DataPoints = append(up.Gauge.DataPoints, otlpNumberDataPoint{
+			Attributes: []otlpKeyValue{
+				otlpString("quick_watch.target.name", state.Target.Name),
+				otlpString("quick_watch.target.url", state.Target.URL),
+			},
+			TimeUnixNano: now,
+			AsInt:        value,
+		})
+	}
+
+	// OTLP bucket counts are per-bucket with a trailing overflow bucket
+	cumulative, count, sum := engine.checkDurations.Snapshot()
+	bucketCounts := make([]string, 0, len(cumulative)+1)
+	var previous uint64
+	for _, c := range cumulative {
+		bucketCounts = append(bucketCounts, strconv.FormatUint(c-previous, 10))
+		previous = c
+	}
+	bucketCounts = append(bucketCounts, strconv.FormatUint(count-previous, 10))
+
+	duration := otlpMetric{Name: "quick_watch.check.duration", Description: "Time spent executing each check cycle", Unit: "s"}
+	duration.Histogram = &otlpHistogram{
+		AggregationTemporality: 2,
+		DataPoints: []otlpHistogramDataPoint{{
+			StartTimeUnixNano: otlpNanos(o.startTime),
+			TimeUnixNano:      now,
+			Count:             strconv.FormatUint(count, 10),
+			Sum:               sum,
+			BucketCounts:      bucketCounts,
+			ExplicitBounds:    engine.checkDurations.Buckets,
+		}},
+	}
+
+	payload := map[string]any{
+		"resourceMetrics": []any{map[string]any{
+			"resource": o.resource(),
+			"scopeMetrics": []any{map[string]any{
+				"scope":   otlpScope{Name: "quick_watch", Version: resolveVersion()},
+				"metrics": []otlpMetric{up, duration},
+			}},
+		}},
+	}
+	return o.post(ctx, "/v1/metrics", payload)
+}
+
+// post sends an OTLP/JSON payload to the collector path
+func (o *OTLPExporter) post(ctx context.Context, path string, payload any) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal OTLP payload: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send OTLP request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d for %s", resp.StatusCode, path)
+	}
+	return nil
+}
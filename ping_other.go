@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package main
+
+import (
+	"errors"
+	"net"
+)
+
+// listenUnprivilegedICMP is unavailable here; ping checks need a raw socket
+func listenUnprivilegedICMP(v4 bool) (net.PacketConn, error) {
+	return nil, errors.New("unprivileged ICMP sockets are not supported on this platform")
+}
@@ -0,0 +1,33 @@
+//go:build linux || darwin
+
+package main
+
+import (
+	"net"
+	"os"
+	"syscall"
+)
+
+// listenUnprivilegedICMP opens an ICMP datagram socket, which Linux (subject to
+// net.ipv4.ping_group_range) and macOS allow without CAP_NET_RAW
+func listenUnprivilegedICMP(v4 bool) (net.PacketConn, error) {
+	family, proto := syscall.AF_INET, syscall.IPPROTO_ICMP
+	var sa syscall.Sockaddr = &syscall.SockaddrInet4{}
+	if !v4 {
+		family, proto = syscall.AF_INET6, syscall.IPPROTO_ICMPV6
+		sa = &syscall.SockaddrInet6{}
+	}
+
+	fd, err := syscall.Socket(family, syscall.SOCK_DGRAM, proto)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	if err := syscall.Bind(fd, sa); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	f := os.NewFile(uintptr(fd), "icmp")
+	defer f.Close()
+	return net.FilePacketConn(f)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// proxyConfig is the parsed proxy_url and no_proxy settings
+type proxyConfig struct {
+	proxyURL *url.URL
+	noProxy  []string // hosts, .domains, IPs, CIDRs, host:port or "*"
+}
+
+// outboundProxy is the active proxy, or nil to fall back to HTTP_PROXY/HTTPS_PROXY
+var outboundProxy atomic.Pointer[proxyConfig]
+
+// parseProxyURL validates a proxy_url setting
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("proxy_url is not a valid URL: %v", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy_url must use http, https or socks5, got %q", raw)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxy_url must include a host, got %q", raw)
+	}
+	return proxyURL, nil
+}
+
+// configureProxy routes checks, alert webhooks and OTLP exports through settings.proxy_url.
+// Without one, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+func configureProxy(settings ServerSettings) {
+	if settings.ProxyURL == "" {
+		outboundProxy.Store(nil)
+		return
+	}
+	proxyURL, err := parseProxyURL(settings.ProxyURL)
+	if err != nil {
+		log.Printf("Warning: %v; connecting directly", err)
+		outboundProxy.Store(nil)
+		return
+	}
+	noProxy := settings.NoProxy
+	if len(noProxy) == 0 {
+		noProxy = strings.Split(cmp.Or(os.Getenv("NO_PROXY"), os.Getenv("no_proxy")), ",")
+	}
+	outboundProxy.Store(&proxyConfig{proxyURL: proxyURL, noProxy: noProxy})
+}
+
+// proxyForRequest is the Proxy func of every outbound transport
+func proxyForRequest(req *http.Request) (*url.URL, error) {
+	config := outboundProxy.Load()
+	if config == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	if bypassProxy(req.URL, config.noProxy) {
+		return nil, nil
+	}
+	return config.proxyURL, nil
+}
+
+// bypassProxy reports whether target should be reached directly: loopback addresses
+// always are, as are hosts matching a no_proxy entry
+func bypassProxy(target *url.URL, noProxy []string) bool {
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[target.Scheme]
+	}
+	ip := net.ParseIP(host)
+	if host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return true
+	}
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if entryHost, entryPort, err := net.SplitHostPort(entry); err == nil {
+			if entryPort != port {
+				continue
+			}
+			entry = entryHost
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// newOutboundTransport clones the default transport with the configured proxy
+func newOutboundTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyForRequest
+	return transport
+}
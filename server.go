@@ -2,23 +2,42 @@ package main
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	qc "github.com/bevelwork/quick_color"
 )
 
+// defaultShutdownTimeout bounds graceful shutdown when shutdown_timeout_seconds is unset
+const defaultShutdownTimeout = 10 * time.Second
+
 // Server represents the quick_watch server
 type Server struct {
 	stateManager *StateManager
 	engine       *TargetEngine
 	server       *http.Server
-	state        string // "stopped", "starting", "running", "stopping"
+	state        string           // "stopped", "starting", "running", "stopping"
+	runCtx       context.Context  // Context the engine runs under; engine restarts reuse it
+	triggers     *TriggerThrottle // Minimum interval between manual triggers (settings.trigger_cooldown_seconds)
 }
 
 // NewServer creates a new quick_watch server
@@ -27,17 +46,34 @@ func NewServer(stateFile string) *Server {
 	return &Server{
 		stateManager: stateManager,
 		state:        "stopped",
+		triggers:     NewTriggerThrottle(),
 	}
 }
 
 // Start starts the server
 func (s *Server) Start(ctx context.Context) error {
 	s.state = "starting"
+	s.runCtx = ctx
 
 	// Load state
 	if err := s.stateManager.Load(); err != nil {
 		return fmt.Errorf("failed to load state: %v", err)
 	}
+	configureLogging(s.stateManager.GetSettings().LogFormat)
+	configureTimezone(s.stateManager.GetSettings())
+	configureProxy(s.stateManager.GetSettings())
+
+	// Make sure configured CA bundles and client certificates load before any checks run
+	if err := s.validateTLSFiles(); err != nil {
+		return err
+	}
+
+	// Loudly flag targets that skip TLS verification
+	for _, target := range s.stateManager.ListTargets() {
+		if target.InsecureSkipVerify {
+			log.Printf("⚠️  WARNING: TLS certificate verification is DISABLED for target %s (%s) - insecure_skip_verify is set", target.Name, target.URL)
+		}
+	}
 
 	// Clean up old diff images on startup
 	if err := s.cleanupDiffImages(); err != nil {
@@ -100,6 +136,8 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/api/status", s.handleStatus)
 	mux.HandleFunc("/api/state", s.handleState)
 	mux.HandleFunc("/api/settings", s.handleSettings)
+	mux.HandleFunc("/api/paging", s.handlePaging)
+	mux.HandleFunc("/api/config/effective", s.handleEffectiveConfig)
 	mux.HandleFunc("/api/acknowledge/", s.handleAcknowledge)
 	mux.HandleFunc("/api/trigger/", s.handleTrigger)
 
@@ -109,6 +147,8 @@ func (s *Server) Start(ctx context.Context) error {
 	// Target pages - root is the main target list view
 	mux.HandleFunc("/targets/", s.handleTargetDetail)
 	mux.HandleFunc("/api/history/", s.handleTargetHistoryAPI)
+	mux.HandleFunc("/api/incidents", s.handleIncidents)
+	mux.HandleFunc("/api/events", s.handleEvents)
 	mux.HandleFunc("/api/screenshots/", s.handleScreenshots)
 	mux.HandleFunc("/", s.handleTargetList) // Root endpoint - main dashboard
 
@@ -116,18 +156,27 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/info", s.handleInfo)
 	mux.HandleFunc("/status", s.handleWebhookStatus)
+	mux.HandleFunc("/status.txt", s.handleStatusText)
+	mux.HandleFunc("/api/overall", s.handleOverall)
+	mux.HandleFunc("/badge", s.handleBadge)
+	mux.HandleFunc("/api/stats", s.handleStats)
+	mux.HandleFunc("/metrics", s.handleMetrics)
 
 	// Server is configured with port from settings (already set above)
 
 	s.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: mux,
+		Addr:    net.JoinHostPort(settings.ListenHost, strconv.Itoa(port)),
+		Handler: s.requireAPIAuth(mux, webhookPath),
 	}
 
 	s.state = "running"
 
 	// Log unified server startup
-	log.Printf("Starting Quick Watch unified server on port %d", port)
+	if settings.ListenHost != "" {
+		logEvent("server.start", "", "Starting Quick Watch unified server on %s", s.server.Addr)
+	} else {
+		logEvent("server.start", "", "Starting Quick Watch unified server on port %d", port)
+	}
 
 	// Use configured server address or localhost
 	displayAddr := serverAddress
@@ -145,19 +194,65 @@ func (s *Server) Start(ctx context.Context) error {
 	// Start server in goroutine
 	go func() {
 		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Printf("Server error: %v", err)
+			logEvent("server.error", "", "Server error: %v", err)
 		}
 	}()
 
 	return nil
 }
 
-// Stop stops the server
+// validateTLSFiles verifies that the global and per-target CA bundles and the
+// per-target client certificates can be loaded
+func (s *Server) validateTLSFiles() error {
+	settings := s.stateManager.GetSettings()
+	if settings.CABundleFile != "" {
+		if _, err := loadCABundle(settings.CABundleFile); err != nil {
+			return fmt.Errorf("invalid ca_bundle_file: %v", err)
+		}
+	}
+	for _, target := range s.stateManager.ListTargets() {
+		if target.CABundleFile != "" {
+			if _, err := loadCABundle(target.CABundleFile); err != nil {
+				return fmt.Errorf("target %s: invalid ca_bundle_file: %v", target.Name, err)
+			}
+		}
+		if target.ClientCertFile != "" || target.ClientKeyFile != "" {
+			if _, err := loadClientCertificate(target.ClientCertFile, target.ClientKeyFile); err != nil {
+				return fmt.Errorf("target %s: %v", target.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Stop stops the server, giving the engine and open connections up to
+// shutdown_timeout_seconds before forcing connections closed
 func (s *Server) Stop(ctx context.Context) error {
 	s.state = "stopping"
 
+	timeout := defaultShutdownTimeout
+	if seconds := s.stateManager.GetSettings().ShutdownTimeoutSeconds; seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Bookend the session before the engine stops, while strategies are still registered
+	if s.engine != nil && s.stateManager.GetSettings().Shutdown.Enabled {
+		s.sendShutdownMessage(ctx)
+	}
+
+	if s.engine != nil {
+		if err := s.engine.Stop(ctx); err != nil {
+			log.Printf("Warning: engine did not stop cleanly: %v", err)
+		}
+	}
+
 	if s.server != nil {
 		if err := s.server.Shutdown(ctx); err != nil {
+			log.Printf("Warning: graceful shutdown timed out after %v, forcing connections closed", timeout)
+			s.server.Close()
+			s.state = "stopped"
 			return err
 		}
 	}
@@ -169,31 +264,31 @@ func (s *Server) Stop(ctx context.Context) error {
 // cleanupDiffImages removes all diff images, baselines, and old current screenshots on startup
 func (s *Server) cleanupDiffImages() error {
 	screenshotPath := "screenshots"
-	
+
 	// Check if directory exists
 	if _, err := os.Stat(screenshotPath); os.IsNotExist(err) {
 		return nil // Directory doesn't exist yet, nothing to clean
 	}
-	
+
 	// Read directory contents
 	files, err := os.ReadDir(screenshotPath)
 	if err != nil {
 		return fmt.Errorf("failed to read screenshots directory: %v", err)
 	}
-	
+
 	// Remove diff images, baselines, and old current screenshots
 	diffCount := 0
 	baselineCount := 0
 	currentCount := 0
-	
+
 	for _, file := range files {
 		if file.IsDir() {
 			continue
 		}
-		
+
 		fileName := file.Name()
 		filePath := filepath.Join(screenshotPath, fileName)
-		
+
 		// Remove diff images
 		if strings.HasSuffix(fileName, "_diff.png") {
 			if err := os.Remove(filePath); err != nil {
@@ -217,12 +312,88 @@ func (s *Server) cleanupDiffImages() error {
 			}
 		}
 	}
-	
+
 	log.Printf("Startup cleanup: Removed %d diff image(s), %d baseline(s), %d old screenshot(s)", diffCount, baselineCount, currentCount)
-	
+
 	return nil
 }
 
+// apiAuthExempt reports whether path stays reachable without settings.api_auth credentials:
+// load balancer health checks, the status badge embedded in READMEs, static assets, the
+// inbound webhook, hooks (which carry their own auth) and acknowledgement links opened from alerts
+func apiAuthExempt(path, webhookPath string) bool {
+	switch {
+	case path == "/health", path == "/badge", path == webhookPath:
+		return true
+	case strings.HasPrefix(path, "/web/"), strings.HasPrefix(path, "/hooks/"), strings.HasPrefix(path, "/api/acknowledge/"):
+		return true
+	}
+	return false
+}
+
+// requireAPIAuth wraps next so that, when settings.api_auth is configured, requests outside
+// apiAuthExempt must present the bearer token or the basic auth credentials. Settings are
+// read per request so changes made through /api/settings apply immediately.
+func (s *Server) requireAPIAuth(next http.Handler, webhookPath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := s.stateManager.GetSettings().APIAuth
+		if auth.BearerToken == "" && auth.Username == "" && auth.Password == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if apiAuthExempt(r.URL.Path, webhookPath) || apiAuthAllows(auth, r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if auth.Username != "" || auth.Password != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="quick_watch"`)
+		}
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// apiAuthAllows accepts either credential: a matching bearer token (for scripts) or matching
+// basic auth (for browsers). Comparisons are constant time.
+func apiAuthAllows(auth HookAuth, r *http.Request) bool {
+	if auth.BearerToken != "" {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(auth.BearerToken)) == 1 {
+			return true
+		}
+	}
+	if auth.Username != "" || auth.Password != "" {
+		if u, p, ok := r.BasicAuth(); ok &&
+			subtle.ConstantTimeCompare([]byte(u), []byte(auth.Username)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(p), []byte(auth.Password)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultHookSignatureHeader carries a hook's HMAC signature when signature_header is unset
+const defaultHookSignatureHeader = "X-Hub-Signature-256"
+
+// maxHookBodyBytes bounds the body a hook reads; larger requests get 413
+const maxHookBodyBytes = 1 << 20
+
+// verifyHookSignature reports whether signature is the HMAC-SHA256 of body under secret,
+// hex or base64 encoded with an optional "sha256=" prefix (as sent by GitHub)
+func verifyHookSignature(secret string, body []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+		return true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	return err == nil && hmac.Equal(decoded, expected)
+}
+
 // registerHookRoutes registers named hook routes from state manager
 func (s *Server) registerHookRoutes(mux *http.ServeMux) {
 	if s.stateManager == nil {
@@ -268,9 +439,31 @@ func (s *Server) registerHookRoutes(mux *http.ServeMux) {
 				}
 			}
 
+			// Signed hooks must carry an HMAC of the exact body before it is parsed
+			payload, err := io.ReadAll(http.MaxBytesReader(wr, r.Body, maxHookBodyBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(wr, fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(wr, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			if h.Auth.HMACSecret != "" {
+				header := h.Auth.SignatureHeader
+				if header == "" {
+					header = defaultHookSignatureHeader
+				}
+				if !verifyHookSignature(h.Auth.HMACSecret, payload, r.Header.Get(header)) {
+					http.Error(wr, "Invalid signature", http.StatusUnauthorized)
+					return
+				}
+			}
+
 			// Build notification from request
 			body := map[string]any{}
-			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.Unmarshal(payload, &body)
 
 			// Resolve message precedence: URL param 'msg' > body.msg > hook default
 			msg := h.Message
@@ -283,6 +476,12 @@ func (s *Server) registerHookRoutes(mux *http.ServeMux) {
 			if msg == "" {
 				msg = "hook triggered"
 			}
+			// Hook metadata supplies defaults; keys sent in the request body take precedence
+			for key, value := range h.Metadata {
+				if _, exists := body[key]; !exists {
+					body[key] = value
+				}
+			}
 			notification := &WebhookNotification{
 				Type:      "hook",
 				Target:    h.Name,
@@ -322,20 +521,22 @@ func (s *Server) registerHookRoutes(mux *http.ServeMux) {
 					// Use acknowledgement-aware method if available
 					if ackSender, ok := strat.(AcknowledgementAwareNotification); ok && ackURL != "" {
 						if err := ackSender.HandleNotificationWithAck(r.Context(), notification, ackURL); err != nil {
-							log.Printf("Hook %s notify via %s failed: %v", h.Name, alertName, err)
+							logEvent("hook.notify_failed", "", "Hook %s notify via %s failed: %v", h.Name, alertName, err)
 						} else {
 							// Track metric: notification sent
 							s.engine.metrics.mutex.Lock()
 							s.engine.metrics.NotificationsSent++
+							s.engine.metrics.NotificationsSentTotal++
 							s.engine.metrics.mutex.Unlock()
 						}
 					} else {
 						if err := strat.HandleNotification(r.Context(), notification); err != nil {
-							log.Printf("Hook %s notify via %s failed: %v", h.Name, alertName, err)
+							logEvent("hook.notify_failed", "", "Hook %s notify via %s failed: %v", h.Name, alertName, err)
 						} else {
 							// Track metric: notification sent
 							s.engine.metrics.mutex.Lock()
 							s.engine.metrics.NotificationsSent++
+							s.engine.metrics.NotificationsSentTotal++
 							s.engine.metrics.mutex.Unlock()
 						}
 					}
@@ -345,7 +546,14 @@ func (s *Server) registerHookRoutes(mux *http.ServeMux) {
 			wr.WriteHeader(http.StatusOK)
 			wr.Write([]byte("OK"))
 		})
-		log.Printf("Hook route registered: %s -> alerts=%v", routePath, hook.Alerts)
+		logEvent("hook.registered", "", "Hook route registered: %s -> alerts=%v", routePath, hook.Alerts)
+		unknown := unknownHookAlerts(hook, func(name string) bool {
+			_, exists := s.engine.notificationStrategies[name]
+			return exists
+		})
+		if len(unknown) > 0 {
+			logEvent("hook.registered", "", "Warning: hook %s references unknown or disabled notifier(s) %v; they will be skipped when the hook fires", name, unknown)
+		}
 	}
 }
 
@@ -369,7 +577,7 @@ func (s *Server) handleWebhook(wr http.ResponseWriter, r *http.Request) {
 
 	// Handle the notification
 	if err := s.engine.HandleWebhookNotification(r.Context(), &notification); err != nil {
-		log.Printf("Error handling webhook notification: %v", err)
+		logEvent("webhook.error", notification.Target, "Error handling webhook notification: %v", err)
 		http.Error(wr, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -383,7 +591,7 @@ func (s *Server) handleWebhookStatus(wr http.ResponseWriter, r *http.Request) {
 	wr.Header().Set("Content-Type", "application/json")
 	wr.WriteHeader(http.StatusOK)
 
-	targets := s.engine.GetTargetStatus()
+	targets := filterTargetsByTag(s.engine.GetTargetStatus(), r.URL.Query().Get("tag"))
 	status := map[string]any{
 		"timestamp": time.Now(),
 		"service":   "quick_watch",
@@ -395,7 +603,10 @@ func (s *Server) handleWebhookStatus(wr http.ResponseWriter, r *http.Request) {
 		targetList[i] = map[string]any{
 			"name":       state.Target.Name,
 			"url":        state.Target.URL,
+			"tags":       state.Target.Tags,
 			"is_down":    state.IsDown,
+			"flapping":   state.FlappingSince != nil,
+			"paused":     state.Target.Paused,
 			"down_since": state.DownSince,
 			"last_check": state.LastCheck,
 		}
@@ -437,28 +648,425 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	targets := s.engine.GetTargetStatus()
+	targets := filterTargetsByTag(s.engine.GetTargetStatus(), r.URL.Query().Get("tag"))
 	status := map[string]any{
-		"timestamp": time.Now(),
-		"service":   "quick_watch",
-		"state":     s.state,
-		"targets":   make([]map[string]any, len(targets)),
+		"timestamp":   time.Now(),
+		"service":     "quick_watch",
+		"state":       s.state,
+		"paging_mode": pagingModeName(s.engine.CriticalOnly()),
+		"targets":     make([]map[string]any, len(targets)),
+		"notifiers":   s.engine.notifierHealth.Snapshot(),
 	}
 
 	targetList := status["targets"].([]map[string]any)
 	for i, state := range targets {
 		targetList[i] = map[string]any{
-			"name":       state.Target.Name,
-			"url":        state.Target.URL,
-			"is_down":    state.IsDown,
-			"down_since": state.DownSince,
-			"last_check": state.LastCheck,
+			"name":            state.Target.Name,
+			"url":             state.Target.URL,
+			"tags":            state.Target.Tags,
+			"is_down":         state.IsDown,
+			"flapping":        state.FlappingSince != nil,
+			"paused":          state.Target.Paused,
+			"down_since":      state.DownSince,
+			"acknowledged_at": state.AcknowledgedAt,
+			"last_check":      state.LastCheck,
 		}
 	}
 
 	json.NewEncoder(w).Encode(status)
 }
 
+// OverallStatus is the aggregate health of all targets served by /api/overall and /badge
+type OverallStatus struct {
+	Status      string    `json:"status"` // "operational" or "outage"
+	Message     string    `json:"message"`
+	Total       int       `json:"total"`
+	Up          int       `json:"up"`
+	Down        int       `json:"down"`
+	Paused      int       `json:"paused"`
+	Pending     int       `json:"pending"` // not checked yet
+	DownTargets []string  `json:"down_targets,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// overallStatus sums up targets; paused targets never count as down
+func overallStatus(targets []*TargetState) OverallStatus {
+	overall := OverallStatus{Total: len(targets), Timestamp: time.Now()}
+	for _, state := range targets {
+		switch {
+		case state.Target.Paused:
+			overall.Paused++
+		case state.IsDown:
+			overall.Down++
+			overall.DownTargets = append(overall.DownTargets, state.Target.Name)
+		case state.LastCheck == nil:
+			overall.Pending++
+		default:
+			overall.Up++
+		}
+	}
+	sort.Strings(overall.DownTargets)
+
+	switch overall.Down {
+	case 0:
+		overall.Status, overall.Message = "operational", "All systems operational"
+	case 1:
+		overall.Status, overall.Message = "outage", "1 target down"
+	default:
+		overall.Status, overall.Message = "outage", fmt.Sprintf("%d targets down", overall.Down)
+	}
+	return overall
+}
+
+// handleOverall reports the aggregate health of all targets (or those tagged ?tag=), answering
+// 503 while any is down so external uptime monitors can watch quick_watch itself
+func (s *Server) handleOverall(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	overall := overallStatus(filterTargetsByTag(s.engine.GetTargetStatus(), r.URL.Query().Get("tag")))
+
+	w.Header().Set("Content-Type", "application/json")
+	if overall.Down > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(overall)
+}
+
+// handleBadge renders the aggregate health as a shields-style SVG badge for READMEs.
+// ?label= changes the left-hand text and ?tag= limits it to tagged targets.
+func (s *Server) handleBadge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	overall := overallStatus(filterTargetsByTag(s.engine.GetTargetStatus(), r.URL.Query().Get("tag")))
+	label := r.URL.Query().Get("label")
+	if label == "" {
+		label = "quick_watch"
+	}
+	value, color := "operational", "#2ea44f"
+	if overall.Down > 0 {
+		value, color = fmt.Sprintf("%d down", overall.Down), "#d73a49"
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	// Image proxies such as GitHub's cache aggressively; the badge should stay live
+	w.Header().Set("Cache-Control", "no-cache, max-age=0")
+	w.Write([]byte(renderBadge(label, value, color)))
+}
+
+// renderBadge draws a two-part badge; widths are estimated from the text length
+func renderBadge(label, value, color string) string {
+	labelWidth := 10 + 7*utf8.RuneCountInString(label)
+	valueWidth := 10 + 7*utf8.RuneCountInString(value)
+	width := labelWidth + valueWidth
+	label, value = html.EscapeString(label), html.EscapeString(value)
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+<title>%s: %s</title>
+<rect width="%d" height="20" rx="3" fill="#555"/>
+<rect x="%d" width="%d" height="20" rx="3" fill="%s"/>
+<rect x="%d" width="4" height="20" fill="%s"/>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%d" y="14">%s</text>
+<text x="%d" y="14">%s</text>
+</g>
+</svg>
+`, width, label, value, label, value, width, labelWidth, valueWidth, color, labelWidth, color, labelWidth/2, label, labelWidth+valueWidth/2, value)
+}
+
+// filterTargetsByTag keeps the targets tagged tag (case-insensitive); an empty tag keeps all
+func filterTargetsByTag(targets []*TargetState, tag string) []*TargetState {
+	if tag == "" {
+		return targets
+	}
+	tagged := make([]*TargetState, 0, len(targets))
+	for _, state := range targets {
+		if hasTag(state.Target.Tags, tag) {
+			tagged = append(tagged, state)
+		}
+	}
+	return tagged
+}
+
+// FleetStats summarizes every target (or those tagged ?tag=) for /api/stats
+type FleetStats struct {
+	Total                  int            `json:"total"`
+	Up                     int            `json:"up"`
+	Down                   int            `json:"down"`
+	Paused                 int            `json:"paused"`
+	Pending                int            `json:"pending"`              // not checked yet
+	AvgResponseTimeMs      float64        `json:"avg_response_time_ms"` // over successful checks in retained history
+	WorstP95Ms             int64          `json:"worst_p95_ms"`
+	WorstP95Target         string         `json:"worst_p95_target,omitempty"`
+	AlertsSentTotal        int            `json:"alerts_sent_total"`
+	NotificationsSentTotal int            `json:"notifications_sent_total"`
+	UptimeDistribution     map[string]int `json:"uptime_distribution"` // targets per 24h uptime bucket
+	Timestamp              time.Time      `json:"timestamp"`
+}
+
+// uptimeBucket names the /api/stats distribution bucket for a 24h uptime window
+func uptimeBucket(uptime UptimeWindow) string {
+	switch {
+	case uptime.Percent == nil:
+		return "no_data"
+	case *uptime.Percent >= 100:
+		return "100%"
+	case *uptime.Percent >= 99.9:
+		return ">=99.9%"
+	case *uptime.Percent >= 99:
+		return ">=99%"
+	case *uptime.Percent >= 95:
+		return ">=95%"
+	default:
+		return "<95%"
+	}
+}
+
+// fleetStats aggregates the targets' histories; paused targets are counted but left out of
+// the response time and uptime figures
+func fleetStats(targets []*TargetState, now time.Time) FleetStats {
+	overall := overallStatus(targets)
+	stats := FleetStats{
+		Total:              overall.Total,
+		Up:                 overall.Up,
+		Down:               overall.Down,
+		Paused:             overall.Paused,
+		Pending:            overall.Pending,
+		UptimeDistribution: map[string]int{"100%": 0, ">=99.9%": 0, ">=99%": 0, ">=95%": 0, "<95%": 0, "no_data": 0},
+		Timestamp:          now,
+	}
+
+	var totalTime int64
+	successful := 0
+	for _, state := range targets {
+		if state.Target.Paused {
+			continue
+		}
+		history := state.GetCheckHistory()
+		for _, entry := range history {
+			if entry.Success {
+				totalTime += entry.ResponseTime
+				successful++
+			}
+		}
+		if p95, ok := responseTimeP95(history); ok && (p95 > stats.WorstP95Ms || stats.WorstP95Target == "") {
+			stats.WorstP95Ms, stats.WorstP95Target = p95, state.Target.Name
+		}
+		stats.UptimeDistribution[uptimeBucket(calculateUptime(history, now)[0])]++
+	}
+	if successful > 0 {
+		stats.AvgResponseTimeMs = float64(totalTime) / float64(successful)
+	}
+	return stats
+}
+
+// responseTimeP95 returns the 95th percentile response time in ms of the successful checks
+// in history, or false when there are none
+func responseTimeP95(history []CheckHistoryEntry) (int64, bool) {
+	var times []int64
+	for _, entry := range history {
+		if entry.Success {
+			times = append(times, entry.ResponseTime)
+		}
+	}
+	if len(times) == 0 {
+		return 0, false
+	}
+	slices.Sort(times)
+	return times[min(int(float64(len(times))*0.95), len(times)-1)], true
+}
+
+// handleStats reports fleet-wide metrics as JSON for dashboards that would otherwise have to
+// walk every target's history
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	stats := fleetStats(filterTargetsByTag(s.engine.GetTargetStatus(), r.URL.Query().Get("tag")), time.Now())
+	s.engine.metrics.mutex.RLock()
+	stats.AlertsSentTotal, stats.NotificationsSentTotal = s.engine.metrics.AlertsSentTotal, s.engine.metrics.NotificationsSentTotal
+	s.engine.metrics.mutex.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// handleStatusText renders the target status table as aligned plain text (ANSI colors with ?color=1)
+func (s *Server) handleStatusText(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	useColor := r.URL.Query().Get("color") == "1"
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(renderStatusTable(s.engine.GetTargetStatus(), useColor)))
+}
+
+// renderStatusTable lays targets out as an aligned table, down targets first, for
+// /status.txt and `list --watch`
+func renderStatusTable(targets []*TargetState, useColor bool) string {
+	sortedTargets := make([]*TargetState, len(targets))
+	copy(sortedTargets, targets)
+	sort.SliceStable(sortedTargets, func(i, j int) bool {
+		if sortedTargets[i].IsDown != sortedTargets[j].IsDown {
+			return sortedTargets[i].IsDown
+		}
+		return sortedTargets[i].Target.Name < sortedTargets[j].Target.Name
+	})
+
+	header := []string{"NAME", "STATUS", "CODE", "RESPONSE", "LAST CHECK", "DOWN FOR", "URL"}
+	rows := make([][]string, 0, len(sortedTargets))
+	rowColors := make([]string, 0, len(sortedTargets))
+	for _, state := range sortedTargets {
+		status := "UP"
+		rowColor := qc.ColorGreen
+		if state.IsDown {
+			status = "DOWN"
+			rowColor = qc.ColorRed
+			if state.AcknowledgedAt != nil {
+				status = "DOWN (ACK)"
+				rowColor = qc.ColorYellow
+			}
+		}
+		code, responseTime, lastCheck := "-", "-", "never"
+		if state.LastCheck != nil {
+			if state.LastCheck.StatusCode > 0 {
+				code = strconv.Itoa(state.LastCheck.StatusCode)
+			}
+			responseTime = state.LastCheck.ResponseTime.Round(time.Millisecond).String()
+			lastCheck = formatTimestamp(state.LastCheck.Timestamp)
+		} else {
+			status = "PENDING"
+			rowColor = ""
+		}
+		downFor := "-"
+		if state.DownSince != nil {
+			downFor = formatDuration(time.Since(*state.DownSince))
+		}
+		rows = append(rows, []string{state.Target.Name, status, code, responseTime, lastCheck, downFor, state.Target.URL})
+		rowColors = append(rowColors, rowColor)
+	}
+
+	// Compute column widths from the uncolored text so ANSI codes don't break alignment
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if n := len([]rune(cell)); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	formatRow := func(row []string, color string) string {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			padded := cell
+			if i < len(row)-1 {
+				padded += strings.Repeat(" ", widths[i]-len([]rune(cell)))
+			}
+			if useColor && color != "" && i == 1 {
+				padded = qc.Colorize(padded, color)
+			}
+			cells[i] = padded
+		}
+		return strings.Join(cells, "  ")
+	}
+
+	var b strings.Builder
+	if useColor {
+		b.WriteString(qc.Colorize(formatRow(header, ""), qc.ColorCyan))
+	} else {
+		b.WriteString(formatRow(header, ""))
+	}
+	b.WriteString("\n")
+	down := 0
+	for i, row := range rows {
+		if sortedTargets[i].IsDown {
+			down++
+		}
+		b.WriteString(formatRow(row, rowColors[i]))
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n%d targets, %d down - %s\n", len(rows), down, formatTimestamp(time.Now()))
+
+	return b.String()
+}
+
+// handleMetrics exposes engine metrics in the Prometheus text exposition format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+
+	cumulative, count, sum := s.engine.checkDurations.Snapshot()
+	b.WriteString("# HELP quick_watch_check_duration_seconds Time spent executing a single check cycle, including alert dispatch.\n")
+	b.WriteString("# TYPE quick_watch_check_duration_seconds histogram\n")
+	for i, upper := range s.engine.checkDurations.Buckets {
+		fmt.Fprintf(&b, "quick_watch_check_duration_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(upper, 'g', -1, 64), cumulative[i])
+	}
+	fmt.Fprintf(&b, "quick_watch_check_duration_seconds_bucket{le=\"+Inf\"} %d\n", count)
+	fmt.Fprintf(&b, "quick_watch_check_duration_seconds_sum %s\n", strconv.FormatFloat(sum, 'g', -1, 64))
+	fmt.Fprintf(&b, "quick_watch_check_duration_seconds_count %d\n", count)
+
+	active, waiting := s.engine.checkLimiter.Stats()
+	b.WriteString("# HELP quick_watch_checks_running Checks currently running.\n")
+	b.WriteString("# TYPE quick_watch_checks_running gauge\n")
+	fmt.Fprintf(&b, "quick_watch_checks_running %d\n", active)
+	b.WriteString("# HELP quick_watch_checks_queued Checks waiting for a max_concurrent_checks slot.\n")
+	b.WriteString("# TYPE quick_watch_checks_queued gauge\n")
+	fmt.Fprintf(&b, "quick_watch_checks_queued %d\n", waiting)
+
+	b.WriteString("# HELP quick_watch_target_up Whether the target's last check succeeded (1) or it is down (0).\n")
+	b.WriteString("# TYPE quick_watch_target_up gauge\n")
+	for _, state := range s.engine.targetStates() {
+		up := 1
+		if state.IsDown {
+			up = 0
+		}
+		fmt.Fprintf(&b, "quick_watch_target_up{%s} %d\n", targetMetricLabels(state.Target), up)
+	}
+	b.WriteString("# HELP quick_watch_target_response_time_seconds Response time of the target's last check.\n")
+	b.WriteString("# TYPE quick_watch_target_response_time_seconds gauge\n")
+	for _, state := range s.engine.targetStates() {
+		if state.LastCheck != nil {
+			fmt.Fprintf(&b, "quick_watch_target_response_time_seconds{%s} %s\n", targetMetricLabels(state.Target), strconv.FormatFloat(state.LastCheck.ResponseTime.Seconds(), 'g', -1, 64))
+		}
+	}
+	b.WriteString("# HELP quick_watch_target_status_code HTTP status code of the target's last check (0 when no response was received).\n")
+	b.WriteString("# TYPE quick_watch_target_status_code gauge\n")
+	for _, state := range s.engine.targetStates() {
+		if state.LastCheck != nil {
+			fmt.Fprintf(&b, "quick_watch_target_status_code{%s} %d\n", targetMetricLabels(state.Target), state.LastCheck.StatusCode)
+		}
+	}
+
+	s.engine.metrics.mutex.RLock()
+	alertsSent, notificationsSent := s.engine.metrics.AlertsSentTotal, s.engine.metrics.NotificationsSentTotal
+	s.engine.metrics.mutex.RUnlock()
+	b.WriteString("# HELP quick_watch_alerts_sent_total DOWN alerts sent since the server started.\n")
+	b.WriteString("# TYPE quick_watch_alerts_sent_total counter\n")
+	fmt.Fprintf(&b, "quick_watch_alerts_sent_total %d\n", alertsSent)
+	b.WriteString("# HELP quick_watch_notifications_sent_total Hook notifications sent since the server started.\n")
+	b.WriteString("# TYPE quick_watch_notifications_sent_total counter\n")
+	fmt.Fprintf(&b, "quick_watch_notifications_sent_total %d\n", notificationsSent)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
+
+// metricLabelEscaper escapes Prometheus label values
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// targetMetricLabels renders the name and url labels for a target's metrics
+func targetMetricLabels(target *Target) string {
+	return fmt.Sprintf(`name="%s",url="%s"`, metricLabelEscaper.Replace(target.Name), metricLabelEscaper.Replace(target.URL))
+}
+
 // handleState handles state requests
 func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -478,17 +1086,49 @@ func (s *Server) handleTargets(w http.ResponseWriter, r *http.Request) {
 		s.handleListTargets(w, r)
 	case "POST":
 		s.handleAddTarget(w, r)
+	case "DELETE":
+		s.handleRemoveTargets(w, r)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
+// handleRemoveTargets removes the targets whose URL or name contains ?match=. Removing
+// every target takes an explicit ?all=true, so a bare DELETE can't empty the config.
+func (s *Server) handleRemoveTargets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	match := query.Get("match")
+	if match == "" && query.Get("all") != "true" {
+		http.Error(w, "match is required (or all=true to remove every target)", http.StatusBadRequest)
+		return
+	}
+
+	removed, err := s.stateManager.RemoveTargets(match)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to remove targets: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(removed) > 0 {
+		s.reloadEngine(nil)
+	}
+	if removed == nil {
+		removed = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{"status": "removed", "count": len(removed), "urls": removed})
+}
+
 // handleListTargets lists all targets
 func (s *Server) handleListTargets(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
 	targets := s.stateManager.ListTargets()
+	for key, target := range targets {
+		targets[key] = maskTargetSecrets(target)
+	}
 	json.NewEncoder(w).Encode(targets)
 }
 
@@ -505,18 +1145,27 @@ func (s *Server) handleAddTarget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.stateManager.AddTarget(target); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to add target: %v", err), http.StatusInternalServerError)
+	// ?on_duplicate= overrides the duplicate_targets setting for this request
+	policy := r.URL.Query().Get("on_duplicate")
+	if policy != "" && !slices.Contains(duplicateTargetPolicies, policy) {
+		http.Error(w, fmt.Sprintf("on_duplicate must be one of %s", strings.Join(duplicateTargetPolicies, ", ")), http.StatusBadRequest)
 		return
 	}
 
-	// Restart targeting engine with new configuration
-	config := s.stateManager.GetTargetConfig()
-	s.engine = NewTargetEngine(config, s.stateManager)
-	if err := s.engine.Start(r.Context()); err != nil {
-		log.Printf("Failed to restart targeting engine: %v", err)
+	if _, err := s.stateManager.AddTargetWithPolicy(target, policy); err != nil {
+		var duplicate *DuplicateTargetError
+		if errors.As(err, &duplicate) {
+			http.Error(w, fmt.Sprintf("Target conflicts with an existing one: %v", err), http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to add target: %v", err), http.StatusInternalServerError)
+		return
 	}
 
+	// Start the added target in the running engine and check it right away
+	s.reloadEngine(nil)
+	s.engine.TriggerCheck(target.URL)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{"status": "added", "url": target.URL})
@@ -534,6 +1183,23 @@ func (s *Server) handleTargetByURL(w http.ResponseWriter, r *http.Request) {
 	// URL decode if needed
 	url := path
 
+	if diagnosisURL, ok := strings.CutSuffix(url, "/diagnosis"); ok && r.Method == "GET" {
+		s.handleTargetDiagnosis(w, diagnosisURL)
+		return
+	}
+	if pauseURL, ok := strings.CutSuffix(url, "/pause"); ok && r.Method == "POST" {
+		s.handleTargetPause(w, pauseURL, true)
+		return
+	}
+	if resumeURL, ok := strings.CutSuffix(url, "/resume"); ok && r.Method == "POST" {
+		s.handleTargetPause(w, resumeURL, false)
+		return
+	}
+	if checkURL, ok := strings.CutSuffix(url, "/check"); ok && r.Method == "POST" {
+		s.handleTargetCheckNow(w, r, checkURL)
+		return
+	}
+
 	switch r.Method {
 	case "GET":
 		target, exists := s.stateManager.GetTarget(url)
@@ -542,7 +1208,48 @@ func (s *Server) handleTargetByURL(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(target)
+		json.NewEncoder(w).Encode(maskTargetSecrets(target))
+
+	case "PUT":
+		var target Target
+		if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if target.URL == "" {
+			target.URL = url
+		}
+		if target.Name == "" {
+			target.Name = fmt.Sprintf("Target-%s", target.URL)
+		}
+		if err := validateTargets(map[string]Target{target.URL: target}, s.stateManager); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid target: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := s.stateManager.UpdateTarget(url, target); err != nil {
+			var duplicate *DuplicateTargetError
+			switch {
+			case errors.Is(err, ErrTargetNotFound):
+				http.Error(w, fmt.Sprintf("Failed to update target: %v", err), http.StatusNotFound)
+			case errors.As(err, &duplicate):
+				http.Error(w, fmt.Sprintf("Target conflicts with an existing one: %v", err), http.StatusConflict)
+			default:
+				http.Error(w, fmt.Sprintf("Failed to update target: %v", err), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Keep history and baselines unless the target now points at a different endpoint
+		var renamed map[string]string
+		if !urlChangedMaterially(url, target.URL) {
+			renamed = map[string]string{target.URL: url}
+		}
+		s.reloadEngine(renamed)
+		s.engine.TriggerCheck(target.URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "updated", "url": target.URL})
 
 	case "DELETE":
 		if err := s.stateManager.RemoveTarget(url); err != nil {
@@ -550,12 +1257,8 @@ func (s *Server) handleTargetByURL(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Restart targeting engine with new configuration
-		config := s.stateManager.GetTargetConfig()
-		s.engine = NewTargetEngine(config, s.stateManager)
-		if err := s.engine.Start(r.Context()); err != nil {
-			log.Printf("Failed to restart targeting engine: %v", err)
-		}
+		// Stop the removed target; the others keep running undisturbed
+		s.reloadEngine(nil)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -566,11 +1269,101 @@ func (s *Server) handleTargetByURL(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleTargetPause pauses or resumes a target; paused targets stay listed but are not
+// checked and send no alerts
+func (s *Server) handleTargetPause(w http.ResponseWriter, url string, paused bool) {
+	if err := s.stateManager.SetTargetPaused(url, paused); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrTargetNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to update target: %v", err), status)
+		return
+	}
+
+	// Keep history and incident state across the reload
+	s.reloadEngine(map[string]string{url: url})
+	status := "paused"
+	if !paused {
+		status = "resumed"
+		s.engine.TriggerCheck(url)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": status, "url": url})
+}
+
+// handleTargetDiagnosis returns why a target is failing, based on its last failed check
+func (s *Server) handleTargetDiagnosis(w http.ResponseWriter, url string) {
+	var diagnosis *TargetDiagnosis
+	if s.engine != nil {
+		diagnosis = s.engine.Diagnose(url)
+	}
+	if diagnosis == nil {
+		http.Error(w, "Target not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diagnosis)
+}
+
+// handleTargetCheckNow checks a target immediately, waits for the result and returns it.
+// The result lands in history and alerts like a scheduled check.
+func (s *Server) handleTargetCheckNow(w http.ResponseWriter, r *http.Request, url string) {
+	target, exists := s.stateManager.GetTarget(url)
+	if !exists || s.engine == nil {
+		http.Error(w, "Target not found", http.StatusNotFound)
+		return
+	}
+	if target.Paused {
+		http.Error(w, "Target is paused; resume it to check it", http.StatusConflict)
+		return
+	}
+	if !s.allowTrigger(w, "check:"+url) {
+		return
+	}
+
+	result, exists := s.engine.CheckNow(r.Context(), url)
+	if !exists {
+		http.Error(w, "Target not found", http.StatusNotFound)
+		return
+	}
+	if result == nil {
+		http.Error(w, "Check did not finish", http.StatusServiceUnavailable)
+		return
+	}
+	s.recordTrigger("check:" + url)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// reloadEngine applies the stored target configuration to the running engine after a
+// target change (see TargetEngine.Reload for how renamed is used)
+func (s *Server) reloadEngine(renamed map[string]string) {
+	stopCtx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+	if err := s.engine.Reload(stopCtx, s.stateManager.GetTargetConfig(), renamed); err != nil {
+		log.Printf("Warning: targeting engine did not reload cleanly: %v", err)
+	}
+	if s.engine.runCtx != nil {
+		return
+	}
+
+	ctx := s.runCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := s.engine.Start(ctx); err != nil {
+		log.Printf("Failed to start targeting engine: %v", err)
+	}
+}
+
 // handleSettings handles settings management
 func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case "GET":
-		settings := s.stateManager.GetSettings()
+		settings := maskServerSettings(s.stateManager.GetSettings())
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(settings)
 
@@ -581,18 +1374,78 @@ func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := s.stateManager.UpdateSettings(settings); err != nil {
-			http.Error(w, fmt.Sprintf("Failed to update settings: %v", err), http.StatusInternalServerError)
+		settings = restoreMaskedSettings(settings, s.stateManager.GetSettings())
+		if err := validateSettings(settings); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid settings: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := s.stateManager.UpdateSettings(settings); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to update settings: %v", err), http.StatusInternalServerError)
+			return
+		}
+		// The check concurrency limit applies without a restart
+		if s.engine != nil {
+			s.engine.SetMaxConcurrentChecks(settings.MaxConcurrentChecks)
+		}
+		configureLogging(settings.LogFormat)
+		configureTimezone(settings)
+		configureProxy(settings)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "updated"})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleEffectiveConfig returns the resolved targets, settings and notifiers with secrets masked
+func (s *Server) handleEffectiveConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(s.stateManager.GetEffectiveConfig())
+}
+
+// pagingModeName renders the paging mode for API responses
+func pagingModeName(criticalOnly bool) string {
+	if criticalOnly {
+		return "critical_only"
+	}
+	return "all"
+}
+
+// handlePaging reports (GET) or toggles (POST {"critical_only": true}) critical-only paging mode
+func (s *Server) handlePaging(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		var request struct {
+			CriticalOnly *bool `json:"critical_only"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CriticalOnly == nil {
+			http.Error(w, `Invalid JSON, expected {"critical_only": true|false}`, http.StatusBadRequest)
 			return
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]string{"status": "updated"})
-
+		s.engine.SetCriticalOnly(*request.CriticalOnly)
+		log.Printf("Paging mode set to %s", pagingModeName(*request.CriticalOnly))
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
+
+	criticalOnly := s.engine.CriticalOnly()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"paging_mode":   pagingModeName(criticalOnly),
+		"critical_only": criticalOnly,
+	})
 }
 
 // handleTrigger handles webhook target trigger requests
@@ -605,6 +1458,16 @@ func (s *Server) handleTrigger(w http.ResponseWriter, r *http.Request) {
 	}
 
 	targetName := path
+	// Only known targets are throttled, so made-up names can't grow the throttle
+	known := s.engine.GetTargetByName(targetName)
+	if known == nil {
+		http.Error(w, fmt.Sprintf("Target not found: %s", targetName), http.StatusNotFound)
+		return
+	}
+	triggerKey := "target:" + known.Target.Name
+	if !s.allowTrigger(w, triggerKey) {
+		return
+	}
 
 	// Get message and duration from request
 	var message string
@@ -651,10 +1514,11 @@ func (s *Server) handleTrigger(w http.ResponseWriter, r *http.Request) {
 	// Trigger the webhook target
 	state, err := s.engine.TriggerWebhookTarget(targetName, message, duration)
 	if err != nil {
-		log.Printf("Error triggering webhook target %s: %v", targetName, err)
+		logEvent("webhook.error", targetName, "Error triggering webhook target %s: %v", targetName, err)
 		http.Error(w, fmt.Sprintf("Failed to trigger target: %v", err), http.StatusBadRequest)
 		return
 	}
+	s.recordTrigger(triggerKey)
 
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
@@ -677,7 +1541,7 @@ func (s *Server) handleTrigger(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(response)
 
-	log.Printf("✅ Webhook target '%s' triggered: %s", targetName, message)
+	logEvent("webhook.triggered", targetName, "✅ Webhook target '%s' triggered: %s", targetName, message)
 }
 
 // handleAcknowledge handles alert acknowledgement requests
@@ -733,8 +1597,8 @@ func (s *Server) handleAcknowledge(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			// Send updated notifications to all strategies
-			for _, strat := range state.AlertStrategies {
+			// Send updated notifications to the strategies that received the DOWN alert
+			for _, strat := range s.engine.routedAlertStrategies(state, "down") {
 				if ackStrat, ok := strat.(AcknowledgementAwareAlert); ok {
 					if err := ackStrat.SendAcknowledgement(r.Context(), state.Target, acknowledgedBy, note, contact); err != nil {
 						log.Printf("Failed to send acknowledgement notification via %s: %v", strat.Name(), err)
@@ -1145,7 +2009,7 @@ func (s *Server) showAcknowledgementSuccess(w http.ResponseWriter, name, urlOrMe
 				return "URL"
 			}
 		}(),
-		urlOrMessage, acknowledgedBy, time.Now().Format("2006-01-02 15:04:05 MST"),
+		urlOrMessage, acknowledgedBy, formatTimestamp(time.Now()),
 		contactSection, noteSection)
 
 	w.Write([]byte(html))
@@ -1160,7 +2024,7 @@ func (s *Server) sendStartupMessage(ctx context.Context) {
 		return
 	}
 
-	targetCount := len(s.engine.targets)
+	targetCount := len(s.engine.targetStates())
 	version := resolveVersion()
 
 	// Send startup message to each configured alert
@@ -1201,6 +2065,52 @@ func (s *Server) sendStartupMessage(ctx context.Context) {
 	}
 }
 
+// sendShutdownMessage warns the configured alerts that monitoring is stopping and,
+// if enabled, sends a final status report
+func (s *Server) sendShutdownMessage(ctx context.Context) {
+	settings := s.stateManager.GetSettings()
+
+	targetCount := len(s.engine.targetStates())
+	downCount := 0
+	for _, state := range s.engine.GetTargetStatus() {
+		if state.IsDown {
+			downCount++
+		}
+	}
+	version := resolveVersion()
+
+	for _, alertName := range settings.Shutdown.Alerts {
+		alertStrategy, exists := s.engine.alertStrategies[alertName]
+		if !exists {
+			log.Printf("Warning: Shutdown alert '%s' not found or not available", alertName)
+			continue
+		}
+		var err error
+		switch strategy := alertStrategy.(type) {
+		case *SlackAlertStrategy:
+			err = strategy.SendShutdownMessage(ctx, version, targetCount, downCount)
+		case *ConsoleAlertStrategy:
+			strategy.SendShutdownMessage(version, targetCount, downCount)
+			continue
+		case *EmailAlertStrategy:
+			err = strategy.SendShutdownMessage(ctx, version, targetCount, downCount)
+		case *FileAlertStrategy:
+			err = strategy.SendShutdownMessage(ctx, version, targetCount, downCount)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to send shutdown message to %s: %v", alertName, err)
+		} else {
+			log.Printf("Shutdown message sent to %s successfully", alertName)
+		}
+	}
+
+	if settings.Shutdown.StatusReport {
+		s.sendStatusReport(ctx, settings.Shutdown.Alerts, nil)
+	}
+}
+
 // checkAllTargetsOnStartup checks all targets and reports their health status
 func (s *Server) checkAllTargetsOnStartup(ctx context.Context) {
 	log.Printf("🔍 Checking all targets on startup...")
@@ -1213,14 +2123,14 @@ func (s *Server) checkAllTargetsOnStartup(ctx context.Context) {
 		// Get the check strategy for this target
 		checkStrategy, exists := s.engine.checkStrategies[target.CheckStrategy]
 		if !exists {
-			log.Printf("Warning: Check strategy '%s' not found for target %s", target.CheckStrategy, target.Name)
+			logEvent("check.error", target.Name, "Warning: Check strategy '%s' not found for target %s", target.CheckStrategy, target.Name)
 			continue
 		}
 
 		// Perform the check
 		result, err := checkStrategy.Check(ctx, &target)
 		if err != nil {
-			log.Printf("Error checking target %s: %v", target.Name, err)
+			logEvent("check.error", target.Name, "Error checking target %s: %v", target.Name, err)
 			continue
 		}
 
@@ -1257,14 +2167,19 @@ func (s *Server) sendHealthStatusToSlack(ctx context.Context, slack *SlackAlertS
 // logHealthStatusToConsole logs health status to console
 func (s *Server) logHealthStatusToConsole(target *Target, result *CheckResult) {
 	if result.Success {
-		log.Printf("✅ %s: UP - Status: %d, Time: %v", target.Name, result.StatusCode, result.ResponseTime)
+		logEvent("check.up", target.Name, "✅ %s: UP - Status: %d, Time: %v", target.Name, result.StatusCode, result.ResponseTime)
 	} else {
-		log.Printf("❌ %s: DOWN - Error: %s", target.Name, result.Error)
+		logEvent("check.down", target.Name, "❌ %s: DOWN - Error: %s", target.Name, result.Error)
 	}
 }
 
 // startStatusReportTicker starts a ticker to send periodic status reports
 func (s *Server) startStatusReportTicker(ctx context.Context, config StatusReportConfig) {
+	if config.Schedule != "" {
+		s.startStatusReportSchedule(ctx, config)
+		return
+	}
+
 	interval := config.Interval
 	if interval <= 0 {
 		interval = 60 // default to 60 minutes
@@ -1279,7 +2194,7 @@ func (s *Server) startStatusReportTicker(ctx context.Context, config StatusRepor
 		for {
 			select {
 			case <-ticker.C:
-				s.sendStatusReport(ctx, config.Alerts)
+				s.sendStatusReport(ctx, config.Alerts, config.Groups)
 			case <-ctx.Done():
 				ticker.Stop()
 				return
@@ -1288,15 +2203,62 @@ func (s *Server) startStatusReportTicker(ctx context.Context, config StatusRepor
 	}()
 }
 
-// sendStatusReport generates and sends a status report
-func (s *Server) sendStatusReport(ctx context.Context, alertNames []string) {
+// startStatusReportSchedule sends status reports at the times given by the
+// status_report.schedule cron expression
+func (s *Server) startStatusReportSchedule(ctx context.Context, config StatusReportConfig) {
+	schedule, err := ParseCronSchedule(config.Schedule)
+	if err != nil {
+		log.Printf("Warning: status reports disabled, invalid schedule: %v", err)
+		return
+	}
+	next := schedule.Next(time.Now())
+	if next.IsZero() {
+		log.Printf("Warning: status reports disabled, schedule %q never fires", config.Schedule)
+		return
+	}
+
+	log.Printf("📊 Status reports enabled: schedule %q to %v, next at %s", config.Schedule, config.Alerts, formatTimestamp(next))
+	log.Printf("   Manual trigger: POST %s/trigger/status_report", s.engine.serverAddress)
+
+	go func() {
+		for !next.IsZero() {
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-timer.C:
+				s.sendStatusReport(ctx, config.Alerts, config.Groups)
+				next = schedule.Next(time.Now())
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// sendStatusReport generates a status report and sends it in full to alertNames, and
+// scoped to each tag in groups to that tag's alerts
+func (s *Server) sendStatusReport(ctx context.Context, alertNames []string, groups map[string][]string) {
 	// Generate the report
 	report := s.engine.GenerateStatusReport()
 
 	log.Printf("📊 Sending status report: %d active, %d resolved, %d alerts, %d notifications",
 		len(report.ActiveOutages), len(report.ResolvedOutages), report.AlertsSent, report.NotificationsSent)
+	s.deliverStatusReport(ctx, report, alertNames)
+
+	tags := make([]string, 0, len(groups))
+	for tag := range groups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		scoped := report.ForTag(tag)
+		log.Printf("📊 Sending %q status report: %d active, %d resolved", tag, len(scoped.ActiveOutages), len(scoped.ResolvedOutages))
+		s.deliverStatusReport(ctx, scoped, groups[tag])
+	}
+}
 
-	// Send to each configured alert strategy
+// deliverStatusReport sends report to each named alert strategy
+func (s *Server) deliverStatusReport(ctx context.Context, report *StatusReportData, alertNames []string) {
 	for _, alertName := range alertNames {
 		if strategy, exists := s.engine.alertStrategies[alertName]; exists {
 			if err := strategy.SendStatusReport(ctx, report); err != nil {
@@ -1308,6 +2270,29 @@ func (s *Server) sendStatusReport(ctx context.Context, alertNames []string) {
 	}
 }
 
+// allowTrigger applies trigger_cooldown_seconds to key, answering 429 with a
+// Retry-After header when it was triggered too recently
+func (s *Server) allowTrigger(w http.ResponseWriter, key string) bool {
+	wait := s.triggers.Wait(key, s.triggerCooldown(), time.Now())
+	if wait > 0 {
+		retryAfter := int((wait + time.Second - 1) / time.Second) // round up
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		http.Error(w, fmt.Sprintf("Too many triggers: try again in %ds", retryAfter), http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
+// recordTrigger starts key's trigger_cooldown_seconds after a successful trigger
+func (s *Server) recordTrigger(key string) {
+	s.triggers.Record(key, s.triggerCooldown(), time.Now())
+}
+
+// triggerCooldown returns settings.trigger_cooldown_seconds as a duration
+func (s *Server) triggerCooldown() time.Duration {
+	return time.Duration(s.stateManager.GetSettings().TriggerCooldownSeconds) * time.Second
+}
+
 // handleTriggerStatusReport handles manual status report trigger requests
 func (s *Server) handleTriggerStatusReport(w http.ResponseWriter, r *http.Request) {
 	// Accept both GET and POST
@@ -1329,7 +2314,7 @@ func (s *Server) handleTriggerStatusReport(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if len(settings.StatusReport.Alerts) == 0 {
+	if len(settings.StatusReport.Alerts) == 0 && len(settings.StatusReport.Groups) == 0 {
 		if r.Method == http.MethodGet {
 			s.showStatusReportError(w, "No alert strategies configured for status reports")
 		} else {
@@ -1338,14 +2323,19 @@ func (s *Server) handleTriggerStatusReport(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if !s.allowTrigger(w, "status_report") {
+		return
+	}
+
 	// Generate and send the status report
 	log.Printf("📊 Manual status report triggered via %s", r.Method)
-	s.sendStatusReport(r.Context(), settings.StatusReport.Alerts)
+	s.sendStatusReport(r.Context(), settings.StatusReport.Alerts, settings.StatusReport.Groups)
+	s.recordTrigger("status_report")
 
 	// Get a fresh report for the response (the previous one was consumed)
 	// We'll generate summary data from the current state
 	activeCount := 0
-	for _, state := range s.engine.targets {
+	for _, state := range s.engine.targetStates() {
 		if state.IsDown {
 			activeCount++
 		}
@@ -1512,7 +2502,7 @@ func (s *Server) showStatusReportSuccess(w http.ResponseWriter, activeOutages in
         </div>
     </div>
 </body>
-</html>`, activeOutages, alertsList, time.Now().Format("2006-01-02 15:04:05 MST"))
+</html>`, activeOutages, alertsList, formatTimestamp(time.Now()))
 
 	w.Write([]byte(html))
 }
@@ -1635,16 +2625,19 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 
 	targets := s.engine.GetTargetStatus()
 
-	// Sort targets: unhealthy first, then healthy
+	// Sort targets: unhealthy first, then healthy, then paused
 	sortedTargets := make([]*TargetState, len(targets))
 	copy(sortedTargets, targets)
 
-	// Separate into two groups
+	// Separate into three groups
 	var unhealthy []*TargetState
 	var healthy []*TargetState
+	var paused []*TargetState
 
 	for _, state := range sortedTargets {
-		if state.IsDown {
+		if state.Target.Paused {
+			paused = append(paused, state)
+		} else if state.IsDown {
 			unhealthy = append(unhealthy, state)
 		} else {
 			healthy = append(healthy, state)
@@ -1652,10 +2645,11 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Combine: unhealthy first
-	sortedTargets = append(unhealthy, healthy...)
+	sortedTargets = append(append(unhealthy, healthy...), paused...)
 
 	// Build target cards
 	targetCards := ""
+	allTags := make(map[string]string) // lowercased tag -> tag as first written
 	for _, state := range sortedTargets {
 		urlSafeName := state.GetURLSafeName()
 		statusClass := "healthy"
@@ -1671,6 +2665,11 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 				statusText = "Down (Acknowledged)"
 			}
 		}
+		if state.Target.Paused {
+			statusClass = "paused"
+			statusIcon = "⏸️"
+			statusText = "Paused"
+		}
 
 		downtime := ""
 		if state.DownSince != nil {
@@ -1681,7 +2680,7 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 		lastCheck := "Never"
 		responseTime := "N/A"
 		if state.LastCheck != nil {
-			lastCheck = state.LastCheck.Timestamp.Format("2006-01-02 15:04:05 MST")
+			lastCheck = formatTimestamp(state.LastCheck.Timestamp)
 			if state.LastCheck.ResponseTime > 0 {
 				// Convert nanoseconds to seconds with 3 significant digits
 				seconds := state.LastCheck.ResponseTime.Seconds()
@@ -1700,8 +2699,21 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 			checkStrategy = "http"
 		}
 
+		insecureBadge := ""
+		if state.Target.InsecureSkipVerify {
+			insecureBadge = `<span class="strategy-badge insecure-badge" title="TLS certificate verification is disabled">⚠️ insecure TLS</span>`
+		}
+
+		tagBadges := ""
+		lowerTags := make([]string, 0, len(state.Target.Tags))
+		for _, tag := range state.Target.Tags {
+			tagBadges += fmt.Sprintf(`<span class="strategy-badge tag-badge">%s</span>`, html.EscapeString(tag))
+			lowerTags = append(lowerTags, strings.ToLower(tag))
+			allTags[strings.ToLower(tag)] = tag
+		}
+
 		targetCards += fmt.Sprintf(`
-			<a href="/targets/%s" class="target-card %s" data-target-name="%s" data-target-url="%s">
+			<a href="/targets/%s" class="target-card %s" data-target-name="%s" data-target-url="%s" data-target-tags="%s">
 				<div class="target-header">
 					<span class="status-icon">%s</span>
 					<h3>%s</h3>
@@ -1715,9 +2727,31 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
 				</div>
 				<div class="target-strategy">
 					<span class="strategy-badge">%s</span>
+					%s
+					%s
 				</div>
 			</a>
-		`, urlSafeName, statusClass, strings.ToLower(state.Target.Name), strings.ToLower(state.Target.URL), statusIcon, state.Target.Name, statusClass, statusText, state.Target.URL, downtime, lastCheck, responseTime, checkStrategy)
+		`, urlSafeName, statusClass, strings.ToLower(state.Target.Name), strings.ToLower(state.Target.URL), html.EscapeString(strings.Join(lowerTags, ",")), statusIcon, state.Target.Name, statusClass, statusText, state.Target.URL, downtime, lastCheck, responseTime, checkStrategy, insecureBadge, tagBadges)
+	}
+
+	// Tag filter options; ?tag= preselects one so filtered views can be bookmarked
+	selectedTag := strings.ToLower(r.URL.Query().Get("tag"))
+	tagKeys := make([]string, 0, len(allTags))
+	for key := range allTags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	tagOptions := `<option value="">All tags</option>`
+	for _, key := range tagKeys {
+		selected := ""
+		if key == selectedTag {
+			selected = " selected"
+		}
+		tagOptions += fmt.Sprintf(`<option value="%s"%s>%s</option>`, html.EscapeString(key), selected, html.EscapeString(allTags[key]))
+	}
+	tagFilterStyle := ""
+	if len(tagKeys) == 0 {
+		tagFilterStyle = ` style="display: none;"`
 	}
 
 	emptyState := ""
@@ -1784,6 +2818,15 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
         .filter-input:focus {
             border-color: #58a6ff;
         }
+        .tag-filter {
+            padding: 10px 15px;
+            background: #161b22;
+            border: 1px solid #30363d;
+            border-radius: 6px;
+            color: #c9d1d9;
+            font-size: 14px;
+            outline: none;
+        }
         .clear-filter-btn {
             padding: 10px 20px;
             background: #21262d;
@@ -1831,6 +2874,10 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
         .target-card.healthy {
             border-left: 4px solid #3fb950;
         }
+        .target-card.paused {
+            border-left: 4px solid #8b949e;
+            opacity: 0.7;
+        }
         .target-header {
             display: flex;
             align-items: center;
@@ -1859,6 +2906,10 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
             background: rgba(248, 81, 73, 0.15);
             color: #f85149;
         }
+        .status-badge.paused {
+            background: rgba(139, 148, 158, 0.15);
+            color: #8b949e;
+        }
         .target-url {
             color: #8b949e;
             font-size: 14px;
@@ -1900,6 +2951,15 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
             text-transform: uppercase;
             letter-spacing: 0.5px;
         }
+        .insecure-badge {
+            background: rgba(210, 153, 34, 0.15);
+            color: #d29922;
+        }
+        .tag-badge {
+            background: rgba(163, 113, 247, 0.15);
+            color: #a371f7;
+            text-transform: none;
+        }
         .empty-state {
             text-align: center;
             padding: 60px 20px;
@@ -1949,14 +3009,19 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
         
         function filterTargets() {
             const filterValue = document.getElementById('filterInput').value.toLowerCase();
+            const tagValue = document.getElementById('tagFilter').value;
             const cards = document.querySelectorAll('.target-card');
             let visibleCount = 0;
             
             cards.forEach(card => {
                 const name = card.getAttribute('data-target-name');
                 const url = card.getAttribute('data-target-url');
+                const tags = card.getAttribute('data-target-tags');
+                const tagList = tags ? tags.split(',') : [];
                 
-                if (name.includes(filterValue) || url.includes(filterValue)) {
+                const matchesText = name.includes(filterValue) || url.includes(filterValue) || tags.includes(filterValue);
+                const matchesTag = !tagValue || tagList.includes(tagValue);
+                if (matchesText && matchesTag) {
                     card.classList.remove('hidden');
                     visibleCount++;
                 } else {
@@ -1966,7 +3031,7 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
             
             // Update count
             const filterCount = document.getElementById('filterCount');
-            if (filterValue) {
+            if (filterValue || tagValue) {
                 filterCount.textContent = visibleCount + ' of ' + cards.length + ' targets';
                 filterCount.style.display = 'inline';
             } else {
@@ -1976,20 +3041,62 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
         
         function clearFilter() {
             document.getElementById('filterInput').value = '';
-            filterTargets();
+            document.getElementById('tagFilter').value = '';
+            filterByTag();
             document.getElementById('filterInput').focus();
         }
         
-        // Auto-refresh every 5 seconds (but don't reload if filtering)
-        setTimeout(() => {
+        // Keep the selected tag in the URL so reloads and bookmarks keep the view
+        function filterByTag() {
+            const tagValue = document.getElementById('tagFilter').value;
+            const url = new URL(window.location.href);
+            if (tagValue) {
+                url.searchParams.set('tag', tagValue);
+            } else {
+                url.searchParams.delete('tag');
+            }
+            history.replaceState(null, '', url);
+            filterTargets();
+        }
+        
+        document.addEventListener('DOMContentLoaded', filterTargets);
+        
+        // Reload immediately when a target goes up or down (live via
+        // /api/events); fall back to polling every 5 seconds when SSE isn't available
+        function reloadUnlessFiltering() {
             const filterValue = document.getElementById('filterInput').value;
             if (!filterValue) {
                 window.location.reload();
             } else {
                 // If filtering, just refresh after clearing filter
-                setTimeout(() => window.location.reload(), 5000);
+                setTimeout(reloadUnlessFiltering, 5000);
+            }
+        }
+        
+        function startPolling() {
+            setTimeout(reloadUnlessFiltering, 5000);
+        }
+        
+        // Fresh check results refresh the cards at most every 5 seconds
+        let refreshPending = false;
+        function scheduleRefresh() {
+            if (!refreshPending) {
+                refreshPending = true;
+                setTimeout(reloadUnlessFiltering, 5000);
             }
-        }, 5000);
+        }
+        
+        if (window.EventSource) {
+            const events = new EventSource('/api/events');
+            events.addEventListener('state', reloadUnlessFiltering);
+            events.addEventListener('check', scheduleRefresh);
+            events.onerror = () => {
+                events.close();
+                startPolling();
+            };
+        } else {
+            startPolling();
+        }
     </script>
 </head>
 <body>
@@ -2004,10 +3111,11 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
                 type="text" 
                 id="filterInput" 
                 class="filter-input" 
-                placeholder="Filter targets by name or URL..." 
+                placeholder="Filter targets by name, URL or tag..." 
                 oninput="filterTargets()"
                 autocomplete="off"
             />
+            <select id="tagFilter" class="tag-filter" onchange="filterByTag()"%s>%s</select>
             <button class="clear-filter-btn" onclick="clearFilter()">Clear Filter</button>
             <span id="filterCount" class="filter-count" style="display: none;"></span>
         </div>
@@ -2027,12 +3135,56 @@ func (s *Server) handleTargetList(w http.ResponseWriter, r *http.Request) {
         </div>
     </div>
 </body>
-</html>`, len(targets), targetCards, emptyState)
+</html>`, len(targets), tagFilterStyle, tagOptions, targetCards, emptyState)
 
 	w.Write([]byte(html))
 }
 
+// diagnosisHTML renders the detail page's Diagnosis box; empty when the target has never failed
+func diagnosisHTML(diagnosis *TargetDiagnosis) string {
+	failure := diagnosis.LastFailure
+	if failure == nil {
+		return ""
+	}
+
+	boxClass := "diagnosis-box"
+	heading := "🩺 Diagnosis"
+	if !diagnosis.IsDown {
+		boxClass += " recovered"
+		heading = "🩺 Diagnosis (last failure, since recovered)"
+	}
+
+	rows := fmt.Sprintf(`<div class="detail-row"><strong>Failure Type:</strong> %s</div>`, diagnosis.FailureType)
+	if diagnosis.FailedAssertion != "" {
+		rows += fmt.Sprintf(`<div class="detail-row"><strong>Failed Assertion:</strong> %s</div>`, diagnosis.FailedAssertion)
+	}
+	if diagnosis.Reason != "" {
+		rows += fmt.Sprintf(`<div class="detail-row"><strong>Reason:</strong> %s</div>`, html.EscapeString(diagnosis.Reason))
+	}
+	if diagnosis.IsDown {
+		rows += fmt.Sprintf(`<div class="detail-row"><strong>Consecutive Failures:</strong> %d</div>`, diagnosis.ConsecutiveFailures)
+		if diagnosis.DownSince != nil {
+			rows += fmt.Sprintf(`<div class="detail-row"><strong>Down Since:</strong> %s (%s)</div>`,
+				formatTimestamp(*diagnosis.DownSince), time.Since(*diagnosis.DownSince).Round(time.Second))
+		}
+	}
+	rows += fmt.Sprintf(`<div class="detail-row"><strong>Failed At:</strong> %s</div>`, formatTimestamp(failure.Timestamp))
+
+	return fmt.Sprintf(`
+	<div class="%s">
+		<h2>%s</h2>
+		%s
+	</div>`, boxClass, heading, rows)
+}
+
+// detailLogEntries is how many recent checks the detail page's log renders
+const detailLogEntries = 100
+
+// maxChartPoints caps how many recent checks the detail page's chart plots
+const maxChartPoints = 500
+
 // handleTargetDetail handles the /targets/{name} endpoint - shows individual target details
+
 func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 	// Extract target name from URL
 	urlSafeName := strings.TrimPrefix(r.URL.Path, "/targets/")
@@ -2072,28 +3224,8 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Calculate p95 response time
-		successfulTimes := []int64{}
-		for _, entry := range history {
-			if entry.Success {
-				successfulTimes = append(successfulTimes, entry.ResponseTime)
-			}
-		}
-		if len(successfulTimes) > 0 {
-			// Sort times to find p95
-			sortedTimes := make([]int64, len(successfulTimes))
-			copy(sortedTimes, successfulTimes)
-			for i := 0; i < len(sortedTimes); i++ {
-				for j := i + 1; j < len(sortedTimes); j++ {
-					if sortedTimes[i] > sortedTimes[j] {
-						sortedTimes[i], sortedTimes[j] = sortedTimes[j], sortedTimes[i]
-					}
-				}
-			}
-			p95Index := int(float64(len(sortedTimes)) * 0.95)
-			if p95Index >= len(sortedTimes) {
-				p95Index = len(sortedTimes) - 1
-			}
-			p95ResponseTime = float64(sortedTimes[p95Index]) / 1000.0 // Convert to seconds
+		if p95, ok := responseTimeP95(history); ok {
+			p95ResponseTime = float64(p95) / 1000.0 // Convert to seconds
 		}
 	}
 
@@ -2116,6 +3248,19 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			p95Str = fmt.Sprintf("%.3g", p95ResponseTime) + "s"
 		}
 
+		uptimeCards := ""
+		for _, uptime := range calculateUptime(history, time.Now()) {
+			coverage := fmt.Sprintf("%d of %d checks succeeded", uptime.Successful, uptime.Checks)
+			if !uptime.Complete {
+				coverage += fmt.Sprintf("; history does not yet cover the full %s", uptime.Window)
+			}
+			uptimeCards += fmt.Sprintf(`
+			<div class="stat-card" title="%s">
+				<div class="stat-label">Uptime (%s)</div>
+				<div class="stat-value" id="uptime-%s">%s</div>
+			</div>`, coverage, uptime.Label(), uptime.Window, uptime)
+		}
+
 		statsHTML = fmt.Sprintf(`
 		<div class="stats-container">
 			<div class="stat-card">
@@ -2129,22 +3274,28 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			<div class="stat-card">
 				<div class="stat-label">Total Checks</div>
 				<div class="stat-value">%d</div>
-			</div>
-		</div>`, avgSizeStr, p95Str, len(history))
+			</div>%s
+		</div>`, avgSizeStr, p95Str, len(history), uptimeCards)
 	}
 
-	// Build chart data (last 100 entries)
+	// Chart the most recent maxChartPoints checks; the log renders the most recent detailLogEntries
 	chartData := []map[string]any{}
 	logEntries := ""
 
 	historyLen := len(history)
 	startIdx := 0
-	if historyLen > 100 {
-		startIdx = historyLen - 100
+	if historyLen > detailLogEntries {
+		startIdx = historyLen - detailLogEntries
+	}
+	logHeader := fmt.Sprintf("showing last %d of %d checks kept", historyLen-startIdx, state.HistoryLimit())
+
+	chartStart := 0
+	if historyLen > maxChartPoints {
+		chartStart = historyLen - maxChartPoints
 	}
 
 	// Build chart data in chronological order (for proper graph display)
-	for i := startIdx; i < historyLen; i++ {
+	for i := chartStart; i < historyLen; i++ {
 		entry := history[i]
 		chartData = append(chartData, map[string]any{
 			"timestamp":        entry.Timestamp.Unix() * 1000, // milliseconds for Chart.js
@@ -2162,10 +3313,10 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 		// Build log entry (most recent at top)
 		statusIcon := "✅"
 		statusClass := "success"
-		
+
 		// Check if this is a warmup/baseline collection entry
 		isWarmup := strings.Contains(entry.ResponseBody, "Warmup:")
-		
+
 		if isWarmup {
 			statusIcon = "ℹ️"
 			statusClass = "info"
@@ -2225,7 +3376,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 
 		// Add full details
 		expandedLines := []string{}
-		expandedLines = append(expandedLines, fmt.Sprintf("Timestamp: %s", entry.Timestamp.Format("2006-01-02 15:04:05 MST")))
+		expandedLines = append(expandedLines, fmt.Sprintf("Timestamp: %s", formatTimestamp(entry.Timestamp)))
 		if entry.StatusCode > 0 {
 			expandedLines = append(expandedLines, fmt.Sprintf("Status Code: %d", entry.StatusCode))
 		}
@@ -2233,6 +3384,9 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			seconds := float64(entry.ResponseTime) / 1000.0
 			expandedLines = append(expandedLines, fmt.Sprintf("Response Time: %.3gs", seconds))
 		}
+		if entry.Timings != nil {
+			expandedLines = append(expandedLines, fmt.Sprintf("Timing: %s", entry.Timings))
+		}
 		if entry.ResponseSize > 0 {
 			sizeStr := ""
 			if entry.ResponseSize < 1024 {
@@ -2285,7 +3439,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			expandedContent += `<div style="margin-top: 12px; padding-top: 12px; border-top: 1px solid #30363d;"></div>`
 			expandedContent += `<div style="font-weight: 600; margin-bottom: 8px;">📸 Visual Comparison:</div>`
 			expandedContent += `<div style="display: grid; grid-template-columns: 1fr 1fr; gap: 12px; margin-top: 8px;">`
-			
+
 			if entry.ScreenshotPath != "" {
 				filename := filepath.Base(entry.ScreenshotPath)
 				expandedContent += fmt.Sprintf(`
@@ -2296,7 +3450,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 						</a>
 					</div>`, filename, filename)
 			}
-			
+
 			if entry.DiffImagePath != "" {
 				filename := filepath.Base(entry.DiffImagePath)
 				expandedContent += fmt.Sprintf(`
@@ -2307,7 +3461,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 						</a>
 					</div>`, filename, filename)
 			}
-			
+
 			expandedContent += `</div>`
 			expandedContent += `<div style="font-size: 11px; color: #8b949e; margin-top: 8px; font-style: italic;">Click images to view full size</div>`
 		}
@@ -2325,7 +3479,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 				%s
 			</div>
 		</div>`, statusClass, entryID,
-			entry.Timestamp.Format("15:04:05"),
+			formatClock(entry.Timestamp),
 			statusIcon,
 			statusText,
 			details,
@@ -2346,6 +3500,14 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 	} else {
 		statusBadge = `<span class="status-badge healthy">✅ Healthy</span>`
 	}
+	flapHidden := " hidden"
+	if state.FlappingSince != nil {
+		flapHidden = ""
+	}
+	if state.Target.Paused {
+		statusBadge = `<span class="status-badge paused" title="Not checked and no alerts are sent until resumed">⏸️ Paused</span>`
+	}
+	statusBadge += fmt.Sprintf(`<span class="flap-badge" title="Changing state too often; DOWN alerts and all-clears are held until it is stable"%s>🔀 Flapping</span>`, flapHidden)
 
 	// Create target title (make it clickable if it's a web URL)
 	targetTitle := state.Target.Name
@@ -2373,7 +3535,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			</button>
 		</div>`
 	}
-	
+
 	// Combine URL and acknowledge button into target-info section
 	targetInfoHTML := fmt.Sprintf(`
 	<div class="target-info">
@@ -2381,9 +3543,12 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 		%s
 	</div>`, state.Target.URL, ackButtonHTML)
 
+	targetInfoHTML += diagnosisHTML(diagnoseTarget(state))
+	targetInfoHTML += backendsHTML(state.Target, history)
+
 	noDataMsg := ""
 	if len(logEntries) == 0 {
-		noDataMsg = `<div class="no-data">No check history available yet. Checks run every 5 seconds.</div>`
+		noDataMsg = fmt.Sprintf(`<div class="no-data">No check history available yet. Checks run every %s.</div>`, s.engine.checkInterval(state.Target))
 	}
 
 	// Build target details section
@@ -2409,11 +3574,15 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 
 		if len(state.Target.Headers) > 0 {
 			headersStr := ""
-			for k, v := range state.Target.Headers {
+			for k, v := range maskTargetSecrets(*state.Target).Headers {
 				headersStr += fmt.Sprintf("%s: %s<br>", k, v)
 			}
 			detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Custom Headers:</strong><br>%s</div>`, headersStr)
 		}
+
+		if state.Target.Body != "" {
+			detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Request Body:</strong> <code>%s</code></div>`, html.EscapeString(state.Target.Body))
+		}
 	} else if checkStrategy == "tcp" {
 		if len(state.Target.Ports) > 0 {
 			portsStr := ""
@@ -2425,6 +3594,26 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 			}
 			detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>TCP Ports:</strong> %s</div>`, portsStr)
 		}
+	} else if checkStrategy == "grpc" {
+		service := "(server)"
+		if state.Target.GRPCService != "" {
+			service = html.EscapeString(state.Target.GRPCService)
+		} else if state.Target.GRPCDiscovery == GRPCDiscoveryReflection {
+			service = "(via reflection)"
+		}
+		transport := "plaintext"
+		if state.Target.GRPCTLS {
+			transport = "TLS"
+		}
+		detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>gRPC Service:</strong> %s</div>`, service)
+		detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Transport:</strong> %s</div>`, transport)
+	} else if checkStrategy == "ping" {
+		pingCount := defaultPingCount
+		if state.Target.PingCount > 0 {
+			pingCount = min(state.Target.PingCount, maxPingCount)
+		}
+		detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Echo Requests:</strong> %d per check</div>`, pingCount)
+		detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Max Packet Loss:</strong> %.0f%%</div>`, state.Target.MaxPacketLoss)
 	} else if checkStrategy == "page-comparison" {
 		visualThreshold := 5.0
 		if state.Target.VisualThreshold > 0 {
@@ -2440,11 +3629,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Add threshold
-	threshold := state.Target.Threshold
-	if threshold == 0 {
-		threshold = 30
-	}
-	detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Threshold:</strong> %d seconds</div>`, threshold)
+	detailsHTML += fmt.Sprintf(`<div class="detail-row"><strong>Threshold:</strong> %s</div>`, describeThreshold(*state.Target))
 
 	// Add alerts
 	if len(state.Target.Alerts) > 0 {
@@ -2535,6 +3720,21 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             background: rgba(187, 128, 9, 0.15);
             color: #d29922;
         }
+        .status-badge.paused {
+            background: rgba(139, 148, 158, 0.15);
+            color: #8b949e;
+        }
+        .flap-badge {
+            padding: 8px 16px;
+            border-radius: 16px;
+            font-size: 14px;
+            font-weight: 600;
+            background: rgba(163, 113, 247, 0.15);
+            color: #a371f7;
+        }
+        .flap-badge[hidden] {
+            display: none;
+        }
         .ack-button-container {
             margin: 20px 0;
             text-align: center;
@@ -2626,6 +3826,14 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
         .pause-button:hover {
             background: rgba(88, 166, 255, 0.25);
         }
+        .pause-button:disabled {
+            opacity: 0.6;
+            cursor: wait;
+        }
+        .terminal-actions {
+            display: flex;
+            gap: 8px;
+        }
         .pause-button.paused {
             background: rgba(187, 128, 9, 0.15);
             color: #d29922;
@@ -2751,6 +3959,22 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             font-weight: 600;
             color: #f0f6fc;
         }
+        .diagnosis-box {
+            background: #161b22;
+            border: 1px solid #30363d;
+            border-left: 4px solid #f85149;
+            border-radius: 6px;
+            padding: 16px;
+            margin-bottom: 20px;
+        }
+        .diagnosis-box.recovered {
+            border-left-color: #8b949e;
+        }
+        .diagnosis-box h2 {
+            font-size: 16px;
+            color: #f0f6fc;
+            margin-bottom: 8px;
+        }
         .target-details {
             background: #161b22;
             border: 1px solid #30363d;
@@ -2825,10 +4049,13 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             .pause-button {
                 width: 100%%;
             }
+            .terminal-actions {
+                flex-direction: column;
+            }
         }
     </style>
 </head>
-<body data-chart-data='%s' data-check-strategy='%s'>
+<body data-chart-data='%s' data-check-strategy='%s' data-timezone='%s'>
     <div class="container">
         <header>
             <a href="/" class="back-button">←</a>
@@ -2848,8 +4075,11 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
         
         <div class="terminal-container">
             <div class="terminal-header">
-                <span>📋 Check History (showing last 100 checks)</span>
-                <button id="pauseButton" class="pause-button" onclick="togglePause()">⏸️ Pause</button>
+                <span>📋 Check History (%s)</span>
+                <div class="terminal-actions">
+                    <button id="checkNowButton" class="pause-button" data-target-url="%s" onclick="checkNow()">🔄 Check now</button>
+                    <button id="pauseButton" class="pause-button" onclick="togglePause()">⏸️ Pause</button>
+                </div>
             </div>
             <div class="terminal-body">
                 %s
@@ -2863,12 +4093,17 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
         /* JavaScript moved to /web/js/target_detail.js */
         const chartData = %s;
         const checkStrategy = '%s';
+        // settings.timezone; undefined keeps times in the browser's zone
+        const displayTimeZone = document.body.dataset.timezone || undefined;
         const isPageComparison = checkStrategy === 'page-comparison';
+        // max_response_time in ms (0 = unset); the chart shades the region above it
+        const maxResponseTimeMs = %d;
         
         // Format labels for display
         const labels = chartData.map(d => {
             const date = new Date(d.timestamp);
             return date.toLocaleTimeString('en-US', { 
+                timeZone: displayTimeZone,
                 hour: '2-digit', 
                 minute: '2-digit', 
                 second: '2-digit',
@@ -2940,6 +4175,17 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                     pointStyle: 'cross',
                     pointHoverRadius: 8,
                     showLine: false
+                }, {
+                    label: 'Max Response Time',
+                    data: chartData.map(() => maxResponseTimeMs / 1000),
+                    hidden: isPageComparison || maxResponseTimeMs <= 0,
+                    borderColor: 'rgba(210, 153, 34, 0.8)',
+                    backgroundColor: 'rgba(210, 153, 34, 0.12)',
+                    borderWidth: 1,
+                    borderDash: [6, 4],
+                    pointRadius: 0,
+                    pointHoverRadius: 0,
+                    fill: 'end'
                 }]
             },
             options: {
@@ -2967,12 +4213,13 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                         bodyColor: '#c9d1d9',
                         padding: 12,
                         displayColors: true,
+                        filter: item => item.datasetIndex !== 2,
                         callbacks: {
                             title: function(context) {
                                 const idx = context[0].dataIndex;
                                 const data = window.chartData || chartData;
                                 const date = new Date(data[idx].timestamp);
-                                return date.toLocaleString();
+                                return date.toLocaleString(undefined, { timeZone: displayTimeZone });
                             },
                             label: function(context) {
                                 const idx = context.dataIndex;
@@ -3065,6 +4312,29 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             }
         }
         
+        // Check the target right away; the result lands in history like a scheduled check
+        async function checkNow() {
+            const button = document.getElementById('checkNowButton');
+            button.disabled = true;
+            button.textContent = '⏳ Checking...';
+            try {
+                const response = await fetch('/api/targets/' + encodeURIComponent(button.dataset.targetUrl) + '/check', { method: 'POST' });
+                if (response.ok) {
+                    const result = await response.json();
+                    button.textContent = result.success ? '✅ Up' : '❌ Down';
+                } else {
+                    button.textContent = '⚠️ ' + (await response.text()).trim();
+                }
+                updateData();
+            } catch (err) {
+                button.textContent = '⚠️ Check failed';
+            }
+            setTimeout(() => {
+                button.disabled = false;
+                button.textContent = '🔄 Check now';
+            }, 3000);
+        }
+        
         // Toggle target details section
         function toggleDetails() {
             const content = document.getElementById('target-details-content');
@@ -3117,7 +4387,10 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                 // Update status badge
                 const statusBadge = document.querySelector('.status-badge');
                 if (statusBadge && data.target) {
-                    if (data.target.is_down) {
+                    if (data.target.paused) {
+                        statusBadge.className = 'status-badge paused';
+                        statusBadge.textContent = '⏸️ Paused';
+                    } else if (data.target.is_down) {
                         if (data.target.acknowledged_at) {
                             statusBadge.className = 'status-badge acked';
                             statusBadge.textContent = '🔔 Acknowledged';
@@ -3130,6 +4403,10 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                         statusBadge.textContent = '✅ Healthy';
                     }
                 }
+                const flapBadge = document.querySelector('.flap-badge');
+                if (flapBadge && data.target) {
+                    flapBadge.hidden = !data.target.flapping;
+                }
                 
                 // Update acknowledge button
                 const ackButtonContainer = document.querySelector('.ack-button-container');
@@ -3146,6 +4423,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                 
                 // Calculate and update statistics
                 updateStatistics(history);
+                updateUptime(data.uptime || []);
                 
                 // Update chart
                 updateChart(history);
@@ -3203,9 +4481,19 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             }
         }
         
+        function updateUptime(windows) {
+            for (const w of windows) {
+                const el = document.getElementById('uptime-' + w.window);
+                if (!el) continue;
+                el.textContent = w.percent === null ? 'N/A' : w.percent.toFixed(2) + '%%';
+                el.previousElementSibling.textContent = 'Uptime (' + (w.span ? 'last ' + w.span : w.window) + ')';
+                el.parentElement.title = w.successful + ' of ' + w.checks + ' checks succeeded' +
+                    (w.complete ? '' : '; history does not yet cover the full ' + w.window);
+            }
+        }
+        
         function updateChart(history) {
-            const last100 = history.slice(-100);
-            const newData = last100.map(entry => ({
+            const newData = history.slice(-%d).map(entry => ({
                 timestamp: new Date(entry.Timestamp).getTime(),
                 success: entry.Success,
                 responseTime: entry.ResponseTime,
@@ -3215,6 +4503,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             const newLabels = newData.map(d => {
                 const date = new Date(d.timestamp);
                 return date.toLocaleTimeString('en-US', { 
+                    timeZone: displayTimeZone,
                     hour: '2-digit', 
                     minute: '2-digit', 
                     second: '2-digit',
@@ -3240,6 +4529,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                 }
             };
             chart.data.datasets[1].data = newData.map(d => !d.success ? (isPageComparison ? 100 : 0) : null);
+            chart.data.datasets[2].data = newData.map(() => maxResponseTimeMs / 1000);
             
             // Store for tooltip callbacks
             window.chartData = newData;
@@ -3251,12 +4541,12 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
             const terminalBody = document.querySelector('.terminal-body');
             if (!terminalBody) return;
             
-            const last100 = history.slice(-100);
+            const recent = history.slice(-%d);
             let newHTML = '';
             
             // Iterate in reverse order to show most recent at top
-            for (let i = last100.length - 1; i >= 0; i--) {
-                const entry = last100[i];
+            for (let i = recent.length - 1; i >= 0; i--) {
+                const entry = recent[i];
                 const entryID = i + 1;
                 
                 // Build log entry
@@ -3310,12 +4600,20 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                 // Build expanded content
                 let expandedLines = [];
                 const timestamp = new Date(entry.Timestamp);
-                expandedLines.push('Timestamp: ' + timestamp.toLocaleString() + ' ' + timestamp.toString().match(/\(([^)]+)\)$/)?.[1] || '');
+                expandedLines.push('Timestamp: ' + timestamp.toLocaleString(undefined, { timeZone: displayTimeZone, timeZoneName: 'short' }));
                 if (entry.StatusCode > 0) expandedLines.push('Status Code: ' + entry.StatusCode);
                 if (entry.Success) {
                     const seconds = entry.ResponseTime / 1000.0;
                     expandedLines.push('Response Time: ' + parseFloat(seconds.toPrecision(3)) + 's');
                 }
+                if (entry.Timings) {
+                    const ms = ns => Math.round(ns / 1e6) + 'ms';
+                    if (entry.Timings.reused) {
+                        expandedLines.push('Timing: TTFB ' + ms(entry.Timings.ttfb) + ' (connection reused)');
+                    } else {
+                        expandedLines.push('Timing: DNS ' + ms(entry.Timings.dns) + ', Connect ' + ms(entry.Timings.connect) + ', TLS ' + ms(entry.Timings.tls) + ', TTFB ' + ms(entry.Timings.ttfb));
+                    }
+                }
                 if (entry.ResponseSize > 0) {
                     let sizeStr = '';
                     if (entry.ResponseSize < 1024) {
@@ -3381,7 +4679,7 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
                 const expandClass = isExpanded ? ' expanded' : '';
                 const displayStyle = isExpanded ? 'block' : 'none';
                 
-                const entryTime = timestamp.toLocaleTimeString('en-US', { hour: '2-digit', minute: '2-digit', second: '2-digit', hour12: false });
+                const entryTime = timestamp.toLocaleTimeString('en-US', { timeZone: displayTimeZone, hour: '2-digit', minute: '2-digit', second: '2-digit', hour12: false });
                 
                 newHTML += '<div class="log-entry-wrapper">';
                 newHTML += '<div class="log-entry ' + statusClass + '" onclick="toggleEntry(' + entryID + ')">';
@@ -3411,15 +4709,100 @@ func (s *Server) handleTargetDetail(w http.ResponseWriter, r *http.Request) {
         // Make chartData global for tooltip callbacks
         window.chartData = chartData;
         
-        // Start auto-update
-        setInterval(updateData, 5000);
+        // Update as soon as this target reports a result (live via
+        // /api/events); fall back to polling every 5 seconds
+        const targetPath = window.location.pathname.replace('/targets/', '');
+        let pollTimer = null;
+        function startPolling() {
+            if (!pollTimer) {
+                pollTimer = setInterval(updateData, 5000);
+            }
+        }
+        
+        if (window.EventSource) {
+            const events = new EventSource('/api/events');
+            events.addEventListener('check', (e) => {
+                const event = JSON.parse(e.data);
+                if (event.url_safe === targetPath) {
+                    updateData();
+                }
+            });
+            events.onerror = () => {
+                events.close();
+                startPolling();
+            };
+        } else {
+            startPolling();
+        }
     </script>
 </body>
-</html>`, state.Target.Name, string(chartDataJSON), checkStrategy, targetTitle, statusBadge, targetInfoHTML, targetDetailsHTML, statsHTML, logEntries, noDataMsg, string(chartDataJSON), checkStrategy)
+</html>`, state.Target.Name, string(chartDataJSON), checkStrategy, html.EscapeString(displayTimezoneName()), targetTitle, statusBadge, targetInfoHTML, targetDetailsHTML, statsHTML, logHeader, html.EscapeString(state.Target.URL), logEntries, noDataMsg, string(chartDataJSON), checkStrategy, state.Target.MaxResponseTime, maxChartPoints, detailLogEntries)
 
 	w.Write([]byte(html))
 }
 
+// eventsHeartbeatInterval keeps idle /api/events connections open through proxies
+const eventsHeartbeatInterval = 15 * time.Second
+
+// handleEvents streams check results and up/down transitions as server-sent events
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	if s.engine == nil || s.engine.events == nil {
+		http.Error(w, "Engine not running", http.StatusServiceUnavailable)
+		return
+	}
+
+	events, unsubscribe := s.engine.events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	// Stop when the server shuts down as well as when the client goes away,
+	// so open streams don't hold up graceful shutdown
+	var shutdown <-chan struct{}
+	if s.runCtx != nil {
+		shutdown = s.runCtx.Done()
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-shutdown:
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
 // handleTargetHistoryAPI handles the API endpoint for fetching target history as JSON
 func (s *Server) handleTargetHistoryAPI(w http.ResponseWriter, r *http.Request) {
 	// Extract target name from URL (format: /api/history/{name})
@@ -3436,8 +4819,26 @@ func (s *Server) handleTargetHistoryAPI(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Get check history
+	// Get check history, optionally limited to a since/until (RFC 3339) range
+	var bounds [2]time.Time
+	for i, param := range []string{"since", "until"} {
+		if v := r.URL.Query().Get(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				http.Error(w, param+" must be an RFC 3339 timestamp", http.StatusBadRequest)
+				return
+			}
+			bounds[i] = t
+		}
+	}
 	history := state.GetCheckHistory()
+	if !bounds[0].IsZero() || !bounds[1].IsZero() {
+		var err error
+		if history, err = state.CheckHistoryBetween(bounds[0], bounds[1]); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	// Return as JSON
 	w.Header().Set("Content-Type", "application/json")
@@ -3448,15 +4849,53 @@ func (s *Server) handleTargetHistoryAPI(w http.ResponseWriter, r *http.Request)
 			"name":     state.Target.Name,
 			"url":      state.Target.URL,
 			"is_down":  state.IsDown,
+			"flapping": state.FlappingSince != nil,
+			"paused":   state.Target.Paused,
 			"url_safe": state.GetURLSafeName(),
 		},
-		"history": history,
-		"count":   len(history),
+		"history":      history,
+		"count":        len(history),
+		"history_size": state.HistoryLimit(),
+		"uptime":       calculateUptime(history, time.Now()),
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleIncidents lists recorded incidents, newest first. Optional query parameters:
+// target (name or URL), status (open or resolved) and limit.
+func (s *Server) handleIncidents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status != "" && status != "open" && status != "resolved" {
+		http.Error(w, "status must be open or resolved", http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "limit must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	incidents := s.engine.incidents.List(query.Get("target"), status, limit)
+	if incidents == nil {
+		incidents = []Incident{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"incidents": incidents,
+		"count":     len(incidents),
+	})
+}
+
 // handleScreenshots serves screenshot images for page-comparison targets
 func (s *Server) handleScreenshots(w http.ResponseWriter, r *http.Request) {
 	// Extract file path from URL (format: /api/screenshots/{filename})
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteStateExtensions are the state file extensions kept in a SQLite database
+// instead of a YAML file
+var sqliteStateExtensions = []string{".db", ".sqlite", ".sqlite3"}
+
+// isSQLiteStatePath reports whether the state at path is kept in SQLite
+func isSQLiteStatePath(path string) bool {
+	return slices.Contains(sqliteStateExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// sqliteSchema keeps each target, alert and hook in its own state row, so a save only
+// rewrites the rows that changed, and check results in an append-only time series
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS state (
+	section TEXT NOT NULL, -- "targets", "alerts" or "hooks"; "" for top-level keys such as settings
+	key     TEXT NOT NULL,
+	yaml    TEXT NOT NULL,
+	PRIMARY KEY (section, key)
+);
+CREATE TABLE IF NOT EXISTS check_results (
+	url              TEXT NOT NULL,
+	checked_at       INTEGER NOT NULL, -- unix nanoseconds
+	success          INTEGER NOT NULL,
+	status_code      INTEGER NOT NULL,
+	response_time_ms INTEGER NOT NULL,
+	entry            TEXT NOT NULL     -- the full CheckHistoryEntry as JSON
+);
+CREATE INDEX IF NOT EXISTS check_results_url_time ON check_results (url, checked_at);
+`
+
+// sqliteStateSections are the top-level state maps stored one entry per row
+var sqliteStateSections = []string{"targets", "alerts", "hooks"}
+
+// sqliteStore is a SQLite database holding quick_watch state and check history
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// openSQLiteStore opens (creating if needed) the SQLite state database at path
+func openSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %v", err)
+	}
+	// One connection serializes writers from the engine and HTTP handlers, and keeps
+	// the pragmas below in effect
+	db.SetMaxOpenConns(1)
+	for _, stmt := range []string{"PRAGMA journal_mode = WAL", "PRAGMA busy_timeout = 5000", sqliteSchema} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to initialize state database: %v", err)
+		}
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+// readState reassembles the stored rows into a YAML state document, or returns nil
+// when the database holds no state yet
+func (s *sqliteStore) readState() ([]byte, error) {
+	rows, err := s.db.Query(`SELECT section, key, yaml FROM state ORDER BY section, key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state database: %v", err)
+	}
+	defer rows.Close()
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := make(map[string]*yaml.Node)
+	for rows.Next() {
+		var section, key, text string
+		if err := rows.Scan(&section, &key, &text); err != nil {
+			return nil, fmt.Errorf("failed to read state database: %v", err)
+		}
+		var value yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &value); err != nil {
+			return nil, fmt.Errorf("invalid state row %s/%s: %v", section, key, err)
+		}
+		parent := root
+		if section != "" {
+			if sections[section] == nil {
+				sections[section] = &yaml.Node{Kind: yaml.MappingNode}
+				root.Content = append(root.Content, stringNode(section), sections[section])
+			}
+			parent = sections[section]
+		}
+		parent.Content = append(parent.Content, stringNode(key), value.Content[0])
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read state database: %v", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	return yaml.Marshal(root)
+}
+
+// writeState stores a YAML state document, rewriting only the rows whose YAML changed
+// and deleting rows for entries the document no longer has
+func (s *sqliteStore) writeState(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to split state: %v", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to split state: not a mapping")
+	}
+
+	rows := make(map[[2]string]string)
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if value.Kind == yaml.MappingNode && slices.Contains(sqliteStateSections, key) {
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				text, err := yaml.Marshal(value.Content[j+1])
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s/%s: %v", key, value.Content[j].Value, err)
+				}
+				rows[[2]string{key, value.Content[j].Value}] = string(text)
+			}
+			continue
+		}
+		text, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %v", key, err)
+		}
+		rows[[2]string{"", key}] = string(text)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to write state database: %v", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := tx.Query(`SELECT section, key FROM state`)
+	if err != nil {
+		return fmt.Errorf("failed to write state database: %v", err)
+	}
+	var stale [][2]string
+	for existing.Next() {
+		var row [2]string
+		if err := existing.Scan(&row[0], &row[1]); err != nil {
+			existing.Close()
+			return fmt.Errorf("failed to write state database: %v", err)
+		}
+		if _, ok := rows[row]; !ok {
+			stale = append(stale, row)
+		}
+	}
+	existing.Close()
+
+	for _, row := range stale {
+		if _, err := tx.Exec(`DELETE FROM state WHERE section = ? AND key = ?`, row[0], row[1]); err != nil {
+			return fmt.Errorf("failed to write state database: %v", err)
+		}
+	}
+	for row, text := range rows {
+		if _, err := tx.Exec(`INSERT INTO state (section, key, yaml) VALUES (?, ?, ?)
+			ON CONFLICT (section, key) DO UPDATE SET yaml = excluded.yaml WHERE yaml != excluded.yaml`,
+			row[0], row[1], text); err != nil {
+			return fmt.Errorf("failed to write state database: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write state database: %v", err)
+	}
+	return nil
+}
+
+// stringNode returns a YAML string scalar
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// RecordCheck appends a check result for url, then prunes the url's results down to
+// the newest keep entries and, when maxAge is set, those no older than maxAge
+func (s *sqliteStore) RecordCheck(url string, entry CheckHistoryEntry, keep int, maxAge time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal check result: %v", err)
+	}
+	success := 0
+	if entry.Success {
+		success = 1
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to record check result: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO check_results (url, checked_at, success, status_code, response_time_ms, entry)
+		VALUES (?, ?, ?, ?, ?, ?)`, url, entry.Timestamp.UnixNano(), success, entry.StatusCode, entry.ResponseTime, string(data)); err != nil {
+		return fmt.Errorf("failed to record check result: %v", err)
+	}
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge).UnixNano()
+		if _, err := tx.Exec(`DELETE FROM check_results WHERE url = ? AND checked_at < ?`, url, cutoff); err != nil {
+			return fmt.Errorf("failed to prune check results: %v", err)
+		}
+	}
+	if keep > 0 {
+		if _, err := tx.Exec(`DELETE FROM check_results WHERE url = ? AND checked_at < (
+			SELECT checked_at FROM check_results WHERE url = ? ORDER BY checked_at DESC LIMIT 1 OFFSET ?)`,
+			url, url, keep-1); err != nil {
+			return fmt.Errorf("failed to prune check results: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to record check result: %v", err)
+	}
+	return nil
+}
+
+// CheckHistory returns url's recorded check results from since to until (zero times
+// leave that end open), oldest first; limit > 0 keeps only the newest limit results
+func (s *sqliteStore) CheckHistory(url string, since, until time.Time, limit int) ([]CheckHistoryEntry, error) {
+	from, to := int64(math.MinInt64), int64(math.MaxInt64)
+	if !since.IsZero() {
+		from = since.UnixNano()
+	}
+	if !until.IsZero() {
+		to = until.UnixNano()
+	}
+	if limit <= 0 {
+		limit = -1 // no limit
+	}
+
+	rows, err := s.db.Query(`SELECT entry FROM (
+		SELECT entry, checked_at FROM check_results
+		WHERE url = ? AND checked_at >= ? AND checked_at <= ?
+		ORDER BY checked_at DESC LIMIT ?
+	) ORDER BY checked_at`, url, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check history: %v", err)
+	}
+	defer rows.Close()
+
+	history := []CheckHistoryEntry{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to query check history: %v", err)
+		}
+		var entry CheckHistoryEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("invalid check result: %v", err)
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query check history: %v", err)
+	}
+	return history, nil
+}
@@ -1,20 +1,79 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// StateManager manages the YAML-backed state for quick_watch
+// stdioStatePath is the --state value that reads state from stdin and writes it to stdout
+const stdioStatePath = "-"
+
+// stateOutput receives state saved to stdioStatePath. main points it at the real stdout
+// and sends human-readable output to stderr so piped state stays clean.
+var stateOutput io.Writer = os.Stdout
+
+// stdoutState holds the last state saved to stdioStatePath until flushStdoutState writes
+// it, so a command that saves several times still emits a single document
+var stdoutState struct {
+	mutex sync.Mutex
+	data  []byte
+}
+
+// flushStdoutState writes the last state saved to stdioStatePath to stateOutput, once; it
+// writes nothing when no state was saved
+func flushStdoutState() error {
+	stdoutState.mutex.Lock()
+	defer stdoutState.mutex.Unlock()
+	if stdoutState.data == nil {
+		return nil
+	}
+	data := stdoutState.data
+	stdoutState.data = nil
+	if _, err := stateOutput.Write(data); err != nil {
+		return fmt.Errorf("failed to write state to stdout: %v", err)
+	}
+	return nil
+}
+
+// stdinState caches state read from stdin, which can only be consumed once per process
+var stdinState struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// readStdinState returns the state piped on stdin
+func readStdinState() ([]byte, error) {
+	stdinState.once.Do(func() {
+		stdinState.data, stdinState.err = io.ReadAll(os.Stdin)
+	})
+	return stdinState.data, stdinState.err
+}
+
+// StateManager manages the YAML-backed state for quick_watch; a state path ending in
+// .db, .sqlite or .sqlite3 keeps the same state in SQLite (see sqliteStore)
 type StateManager struct {
 	filePath string
 	state    *WatchState
 	mutex    sync.RWMutex
+	envRefs  map[string]string // ${VAR} references expanded on load, restored on save
+	store    *sqliteStore      // opened on first use for SQLite state paths
 }
 
 // WatchState represents the complete state of the watch system
@@ -30,14 +89,97 @@ type WatchState struct {
 
 // ServerSettings represents server configuration
 type ServerSettings struct {
-	WebhookPort             int                `yaml:"webhook_port"`
-	WebhookPath             string             `yaml:"webhook_path"`
-	ServerAddress           string             `yaml:"server_address,omitempty"` // public-facing server address for URLs (e.g., "https://monitor.example.com:8080")
-	CheckInterval           int                `yaml:"check_interval"`           // seconds (default: 5s)
-	DefaultThreshold        int                `yaml:"default_threshold"`        // seconds (default: 30s)
-	Startup                 StartupConfig      `yaml:"startup"`                  // startup message configuration
-	AcknowledgementsEnabled bool               `yaml:"acknowledgements_enabled"` // enable alert acknowledgements
-	StatusReport            StatusReportConfig `yaml:"status_report,omitempty"`  // periodic status report configuration
+	WebhookPort              int                 `yaml:"webhook_port"`
+	WebhookPath              string              `yaml:"webhook_path"`
+	ListenHost               string              `yaml:"listen_host,omitempty"`                 // interface the server binds (e.g., "127.0.0.1"; default: all interfaces)
+	ServerAddress            string              `yaml:"server_address,omitempty"`              // public-facing server address for URLs (e.g., "https://monitor.example.com:8080")
+	CheckInterval            int                 `yaml:"check_interval"`                        // seconds (default: 5s)
+	DefaultThreshold         int                 `yaml:"default_threshold"`                     // seconds (default: 30s)
+	Startup                  StartupConfig       `yaml:"startup"`                               // startup message configuration
+	Shutdown                 ShutdownConfig      `yaml:"shutdown,omitempty"`                    // graceful shutdown message configuration
+	AcknowledgementsEnabled  bool                `yaml:"acknowledgements_enabled"`              // enable alert acknowledgements
+	StatusReport             StatusReportConfig  `yaml:"status_report,omitempty"`               // periodic status report configuration
+	ShutdownTimeoutSeconds   int                 `yaml:"shutdown_timeout_seconds,omitempty"`    // graceful shutdown budget in seconds (default: 10)
+	CABundleFile             string              `yaml:"ca_bundle_file,omitempty"`              // PEM CA bundle trusted for outbound HTTPS checks in addition to system roots
+	InitialGraceSeconds      int                 `yaml:"initial_grace_seconds,omitempty"`       // extra seconds before alerting on targets that have never succeeded (default: 0)
+	RecoveryThreshold        int                 `yaml:"recovery_threshold,omitempty"`          // consecutive successful checks before a down target recovers (default: 1)
+	RecoveryCooldownSeconds  int                 `yaml:"recovery_cooldown_seconds,omitempty"`   // seconds after an ALL CLEAR during which a new failure's DOWN alert waits (default: 0)
+	NotifierFailureThreshold int                 `yaml:"notifier_failure_threshold,omitempty"`  // consecutive failed sends before a notifier is unhealthy and falls back (default: 3)
+	RequireAckForAutoresolve bool                `yaml:"require_ack_for_autoresolve,omitempty"` // send "resolved without acknowledgement" instead of all-clear for unacked incidents
+	HistoryRetentionHours    int                 `yaml:"history_retention_hours,omitempty"`     // drop check history older than this many hours (default: 0, count cap only)
+	CheckHistorySize         int                 `yaml:"check_history_size,omitempty"`          // checks kept in each target's history (default: 1000)
+	MaxConcurrentChecks      int                 `yaml:"max_concurrent_checks,omitempty"`       // checks allowed to run at once across all targets; others queue (default: 0, unlimited)
+	TriggerCooldownSeconds   int                 `yaml:"trigger_cooldown_seconds,omitempty"`    // minimum seconds between accepted manual triggers of each target and of the status report (default: 0, no limit)
+	DefaultHeaders           map[string]string   `yaml:"default_headers,omitempty"`             // headers sent with every HTTP check; a target's own headers win (User-Agent defaults to quick_watch/<version>)
+	MaxBodyStoreKB           int                 `yaml:"max_body_store_kb,omitempty"`           // KB of response body kept in check history for targets without their own max_body_store_kb (default: 10)
+	CaptureAllBodies         bool                `yaml:"capture_all_bodies,omitempty"`          // keep text bodies of every content type in check history, not only JSON
+	ProxyURL                 string              `yaml:"proxy_url,omitempty"`                   // HTTP(S) or SOCKS5 proxy for checks, alert webhooks and OTLP exports (default: HTTP_PROXY/HTTPS_PROXY)
+	NoProxy                  []string            `yaml:"no_proxy,omitempty"`                    // hosts, .domains, IPs or CIDRs reached directly (default: NO_PROXY); loopback always is
+	DuplicateTargets         string              `yaml:"duplicate_targets,omitempty"`           // "error" (default), "overwrite" or "merge" when an added target's URL or name is taken
+	OTLPEnabled              bool                `yaml:"otlp_enabled,omitempty"`                // export check spans and target metrics via OTLP/HTTP
+	OTLPEndpoint             string              `yaml:"otlp_endpoint,omitempty"`               // OTLP/HTTP collector base URL (e.g., "http://localhost:4318")
+	Debug                    bool                `yaml:"debug,omitempty"`                       // log engine diagnostics such as check retry attempts
+	LogFormat                string              `yaml:"log_format,omitempty"`                  // "text" (default) or "json" for one structured object per log line
+	Timezone                 string              `yaml:"timezone,omitempty"`                    // IANA zone (e.g., "UTC", "Europe/Berlin") for timestamps in alerts, the web UI and reports (default: local)
+	AlertBackoff             AlertBackoffConfig  `yaml:"alert_backoff,omitempty"`               // re-alert schedule during a sustained outage
+	APIAuth                  HookAuth            `yaml:"api_auth,omitempty"`                    // credentials required for the dashboard, /targets and /api/* (default: none)
+	AckTTL                   int                 `yaml:"ack_ttl,omitempty"`                     // seconds an acknowledgement holds while the target stays down before alerts resume (default: 0, never expires)
+	FlapDetection            FlapDetectionConfig `yaml:"flap_detection,omitempty"`              // hold up/down alerts for targets that keep changing state
+	Escalation               []EscalationStage   `yaml:"escalation,omitempty"`                  // stages paged while a DOWN incident stays unacknowledged; a target's own escalation wins
+}
+
+// FlapDetectionConfig marks a target flapping when it changes state too often, so that
+// one "flapping" alert replaces a stream of alternating DOWN alerts and all-clears
+type FlapDetectionConfig struct {
+	Transitions   int `yaml:"transitions,omitempty"`    // a target with more up/down changes than this within the window is flapping (default: 0, off)
+	WindowSeconds int `yaml:"window_seconds,omitempty"` // window for counting changes; a flapping target is stable again after a window without one (default: 600)
+}
+
+// defaultFlapWindowSeconds is the flap detection window when window_seconds is unset
+const defaultFlapWindowSeconds = 600
+
+// Window returns the flap detection window
+func (c FlapDetectionConfig) Window() time.Duration {
+	if c.WindowSeconds <= 0 {
+		return defaultFlapWindowSeconds * time.Second
+	}
+	return time.Duration(c.WindowSeconds) * time.Second
+}
+
+// AlertBackoffConfig sets how often DOWN alerts repeat while an incident stays unacknowledged
+type AlertBackoffConfig struct {
+	InitialSeconds int     `yaml:"initial_seconds,omitempty"` // wait after the first DOWN alert (default: 60)
+	Multiplier     float64 `yaml:"multiplier,omitempty"`      // growth of the wait after each re-alert (default: 5)
+	MaxSeconds     int     `yaml:"max_seconds,omitempty"`     // longest wait between re-alerts (default: 900)
+}
+
+// Default re-alert schedule: 1m, 5m, then every 15m
+const (
+	defaultAlertBackoffInitialSeconds = 60
+	defaultAlertBackoffMultiplier     = 5
+	defaultAlertBackoffMaxSeconds     = 900
+)
+
+// Delay returns how long to wait after the alertCount-th alert of an incident before
+// re-alerting
+func (c AlertBackoffConfig) Delay(alertCount int) time.Duration {
+	initial := float64(c.InitialSeconds)
+	if initial <= 0 {
+		initial = defaultAlertBackoffInitialSeconds
+	}
+	multiplier := c.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultAlertBackoffMultiplier
+	}
+	maxSeconds := float64(c.MaxSeconds)
+	if maxSeconds <= 0 {
+		maxSeconds = defaultAlertBackoffMaxSeconds
+	}
+	delay := initial
+	for i := 1; i < alertCount && delay < maxSeconds; i++ {
+		delay *= multiplier
+	}
+	return time.Duration(min(delay, maxSeconds) * float64(time.Second))
 }
 
 // StartupConfig represents startup message configuration
@@ -47,11 +189,20 @@ type StartupConfig struct {
 	CheckAllTargets bool     `yaml:"check_all_targets"` // check all targets on startup
 }
 
+// ShutdownConfig represents graceful shutdown message configuration
+type ShutdownConfig struct {
+	Enabled      bool     `yaml:"enabled"`       // send a "monitoring stopping" message on graceful shutdown
+	Alerts       []string `yaml:"alerts"`        // list of alert strategies to use
+	StatusReport bool     `yaml:"status_report"` // also send a final status report to the same alerts
+}
+
 // StatusReportConfig represents periodic status report configuration
 type StatusReportConfig struct {
-	Enabled  bool     `yaml:"enabled"`  // enable periodic status reports
-	Interval int      `yaml:"interval"` // interval in minutes (default: 60)
-	Alerts   []string `yaml:"alerts"`   // list of alert strategies to send reports to
+	Enabled  bool                `yaml:"enabled"`            // enable periodic status reports
+	Interval int                 `yaml:"interval"`           // interval in minutes (default: 60)
+	Schedule string              `yaml:"schedule,omitempty"` // cron expression such as "0 9 * * *" (local time); overrides interval
+	Alerts   []string            `yaml:"alerts"`             // list of alert strategies to send reports to
+	Groups   map[string][]string `yaml:"groups,omitempty"`   // target tag -> alerts that get a report covering only targets with that tag
 }
 
 // NewStateManager creates a new state manager
@@ -85,22 +236,54 @@ func (sm *StateManager) Load() error {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
-	// Check if file exists
-	if _, err := os.Stat(sm.filePath); os.IsNotExist(err) {
-		// Create directory if it doesn't exist
-		dir := filepath.Dir(sm.filePath)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory: %v", err)
+	var data []byte
+	if sm.filePath == stdioStatePath {
+		// Empty stdin starts from the default state without echoing it
+		stdin, err := readStdinState()
+		if err != nil {
+			return fmt.Errorf("failed to read state from stdin: %v", err)
+		}
+		if len(bytes.TrimSpace(stdin)) == 0 {
+			return nil
+		}
+		data = stdin
+	} else if isSQLiteStatePath(sm.filePath) {
+		store, err := sm.openStoreUnlocked()
+		if err != nil {
+			return err
+		}
+		if data, err = store.readState(); err != nil {
+			return err
+		}
+		if data == nil {
+			// Save initial state
+			return sm.saveUnlocked()
+		}
+	} else {
+		// Check if file exists
+		if _, err := os.Stat(sm.filePath); os.IsNotExist(err) {
+			// Create directory if it doesn't exist
+			dir := filepath.Dir(sm.filePath)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %v", err)
+			}
+			// Save initial state
+			return sm.saveUnlocked()
+		}
+
+		// Read and parse YAML file
+		fileData, err := os.ReadFile(sm.filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read state file: %v", err)
 		}
-		// Save initial state
-		return sm.saveUnlocked()
+		data = fileData
 	}
 
-	// Read and parse YAML file
-	data, err := os.ReadFile(sm.filePath)
+	data, envRefs, err := expandEnvYAML(data)
 	if err != nil {
-		return fmt.Errorf("failed to read state file: %v", err)
+		return fmt.Errorf("failed to expand environment variables in state file: %v", err)
 	}
+	sm.envRefs = envRefs
 
 	if err := yaml.Unmarshal(data, sm.state); err != nil {
 		return fmt.Errorf("failed to parse state file: %v", err)
@@ -142,6 +325,11 @@ func (sm *StateManager) Load() error {
 		}
 	}
 
+	for url, target := range sm.state.Targets {
+		applyRetryOnFailureAlias(&target)
+		sm.state.Targets[url] = target
+	}
+
 	return nil
 }
 
@@ -154,12 +342,36 @@ func (sm *StateManager) Save() error {
 
 // saveUnlocked saves the state without acquiring the lock (internal use)
 func (sm *StateManager) saveUnlocked() error {
-	sm.state.Updated = time.Now()
+	return sm.writeStateUnlocked(sm.state, sm.envRefs)
+}
+
+// writeStateUnlocked writes state to the state file with envRefs restored, so a
+// replacement state can be saved before it is swapped in
+func (sm *StateManager) writeStateUnlocked(state *WatchState, envRefs map[string]string) error {
+	state.Updated = time.Now()
 
-	data, err := yaml.Marshal(sm.state)
+	data, err := yaml.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %v", err)
 	}
+	if data, err = restoreEnvYAML(data, envRefs); err != nil {
+		return fmt.Errorf("failed to restore environment references: %v", err)
+	}
+
+	if sm.filePath == stdioStatePath {
+		stdoutState.mutex.Lock()
+		stdoutState.data = data
+		stdoutState.mutex.Unlock()
+		return nil
+	}
+
+	if isSQLiteStatePath(sm.filePath) {
+		store, err := sm.openStoreUnlocked()
+		if err != nil {
+			return err
+		}
+		return store.writeState(data)
+	}
 
 	if err := os.WriteFile(sm.filePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write state file: %v", err)
@@ -168,11 +380,155 @@ func (sm *StateManager) saveUnlocked() error {
 	return nil
 }
 
+// openStoreUnlocked opens the SQLite state database on first use, creating its directory
+func (sm *StateManager) openStoreUnlocked() (*sqliteStore, error) {
+	if sm.store != nil {
+		return sm.store, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(sm.filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %v", err)
+	}
+	store, err := openSQLiteStore(sm.filePath)
+	if err != nil {
+		return nil, err
+	}
+	sm.store = store
+	return store, nil
+}
+
+// historyStore returns the database check history is recorded in, or nil when state
+// isn't kept in SQLite
+func (sm *StateManager) historyStore() *sqliteStore {
+	if !isSQLiteStatePath(sm.filePath) {
+		return nil
+	}
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	store, err := sm.openStoreUnlocked()
+	if err != nil {
+		log.Printf("Warning: %v; check history won't be persisted", err)
+		return nil
+	}
+	return store
+}
+
 // AddTarget adds a new target to the state
 func (sm *StateManager) AddTarget(target Target) error {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
+	return sm.putTargetUnlocked(target)
+}
+
+// Policies for adding a target whose URL or name is already in use
+const (
+	DuplicateTargetsError     = "error"     // reject the new target
+	DuplicateTargetsOverwrite = "overwrite" // replace the stored target
+	DuplicateTargetsMerge     = "merge"     // apply the new target's non-empty fields to the stored one
+)
+
+// duplicateTargetPolicies lists the valid duplicate_targets values
+var duplicateTargetPolicies = []string{DuplicateTargetsError, DuplicateTargetsOverwrite, DuplicateTargetsMerge}
+
+// ErrTargetNotFound is returned for a target URL that is not in the state
+var ErrTargetNotFound = errors.New("target not found")
+
+// DuplicateTargetError reports a new target that collides with a stored one
+type DuplicateTargetError struct {
+	Target   Target // the target being added
+	Existing Target // the stored target with the same URL or name
+}
+
+func (e *DuplicateTargetError) Error() string {
+	if e.Existing.URL == e.Target.URL {
+		return fmt.Sprintf("target %s already exists as %q", e.Target.URL, e.Existing.Name)
+	}
+	return fmt.Sprintf("target name %q is already used by %s", e.Target.Name, e.Existing.URL)
+}
+
+// AddTargetWithPolicy adds a target, resolving a clash with a stored target of the same URL
+// or name by policy ("" uses the duplicate_targets setting). It returns the target as stored.
+func (sm *StateManager) AddTargetWithPolicy(target Target, policy string) (Target, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	if policy == "" {
+		policy = cmp.Or(sm.state.Settings.DuplicateTargets, DuplicateTargetsError)
+	}
+	if !slices.Contains(duplicateTargetPolicies, policy) {
+		return Target{}, fmt.Errorf("duplicate policy must be one of %s, got %q", strings.Join(duplicateTargetPolicies, ", "), policy)
+	}
+
+	// A name taken by another URL is always a conflict: overwriting or merging would
+	// replace a target the caller did not name
+	for url, other := range sm.state.Targets {
+		if url != target.URL && target.Name != "" && other.Name == target.Name {
+			return Target{}, &DuplicateTargetError{Target: target, Existing: other}
+		}
+	}
+	if existing, exists := sm.state.Targets[target.URL]; exists {
+		switch policy {
+		case DuplicateTargetsError:
+			return Target{}, &DuplicateTargetError{Target: target, Existing: existing}
+		case DuplicateTargetsMerge:
+			target = mergeTarget(existing, target)
+			if err := validateTargetsWithAlerts(map[string]Target{target.URL: target}, sm.state.Alerts); err != nil {
+				return Target{}, err
+			}
+		}
+	}
+
+	if err := sm.putTargetUnlocked(target); err != nil {
+		return Target{}, err
+	}
+	return sm.state.Targets[target.URL], nil
+}
+
+// mergeTarget applies the non-empty fields of update over existing; maps such as headers
+// are combined key by key, with update winning
+func mergeTarget(existing, update Target) Target {
+	merged := existing
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	updateValue := reflect.ValueOf(update)
+	for i := range updateValue.NumField() {
+		field := updateValue.Field(i)
+		if field.IsZero() {
+			continue
+		}
+		current := mergedValue.Field(i)
+		if field.Kind() == reflect.Map && !current.IsNil() {
+			combined := reflect.MakeMap(field.Type())
+			for _, source := range []reflect.Value{current, field} {
+				iter := source.MapRange()
+				for iter.Next() {
+					combined.SetMapIndex(iter.Key(), iter.Value())
+				}
+			}
+			field = combined
+		}
+		current.Set(field)
+	}
+	return merged
+}
+
+// UpdateTarget replaces the target stored under oldURL, re-keying it if the URL changed
+func (sm *StateManager) UpdateTarget(oldURL string, target Target) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	if _, exists := sm.state.Targets[oldURL]; !exists {
+		return fmt.Errorf("%w: %s", ErrTargetNotFound, oldURL)
+	}
+	// Moving to a URL that belongs to another target would silently replace that target
+	if other, exists := sm.state.Targets[target.URL]; exists && target.URL != oldURL {
+		return &DuplicateTargetError{Target: target, Existing: other}
+	}
+	delete(sm.state.Targets, oldURL)
+	return sm.putTargetUnlocked(target)
+}
+
+// putTargetUnlocked applies defaults and stores a target keyed by URL; callers hold sm.mutex
+func (sm *StateManager) putTargetUnlocked(target Target) error {
 	// Use URL as key for uniqueness
 	key := target.URL
 	if target.Name == "" {
@@ -183,7 +539,7 @@ func (sm *StateManager) AddTarget(target Target) error {
 	if target.Method == "" {
 		target.Method = "GET"
 	}
-	if target.Threshold == 0 {
+	if target.Threshold == 0 && target.DownAfterFailures == 0 {
 		target.Threshold = sm.state.Settings.DefaultThreshold
 	}
 	if target.CheckStrategy == "" {
@@ -192,11 +548,26 @@ func (sm *StateManager) AddTarget(target Target) error {
 	if target.Headers == nil {
 		target.Headers = make(map[string]string)
 	}
+	applyRetryOnFailureAlias(&target)
 
 	sm.state.Targets[key] = target
 	return sm.saveUnlocked()
 }
 
+// SetTargetPaused pauses or resumes the target with the given URL and saves the state
+func (sm *StateManager) SetTargetPaused(url string, paused bool) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	target, exists := sm.state.Targets[url]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTargetNotFound, url)
+	}
+	target.Paused = paused
+	sm.state.Targets[url] = target
+	return sm.saveUnlocked()
+}
+
 // RemoveTarget removes a target by URL
 func (sm *StateManager) RemoveTarget(url string) error {
 	sm.mutex.Lock()
@@ -210,6 +581,34 @@ func (sm *StateManager) RemoveTarget(url string) error {
 	return sm.saveUnlocked()
 }
 
+// RemoveTargets removes every target whose URL or name contains match (case-insensitive;
+// "" matches all) and returns the removed URLs, sorted
+func (sm *StateManager) RemoveTargets(match string) ([]string, error) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	var removed []string
+	for url, target := range sm.state.Targets {
+		if targetMatches(target, match) {
+			removed = append(removed, url)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	for _, url := range removed {
+		delete(sm.state.Targets, url)
+	}
+	slices.Sort(removed)
+	return removed, sm.saveUnlocked()
+}
+
+// targetMatches reports whether the target's URL or name contains match, ignoring case
+func targetMatches(target Target, match string) bool {
+	match = strings.ToLower(match)
+	return strings.Contains(strings.ToLower(target.URL), match) || strings.Contains(strings.ToLower(target.Name), match)
+}
+
 // GetTarget retrieves a target by URL
 func (sm *StateManager) GetTarget(url string) (Target, bool) {
 	sm.mutex.RLock()
@@ -278,10 +677,319 @@ func (sm *StateManager) GetStateInfo() map[string]interface{} {
 		"created":  sm.state.Created,
 		"updated":  sm.state.Updated,
 		"targets":  len(sm.state.Targets),
-		"settings": sm.state.Settings,
+		"settings": maskServerSettings(sm.state.Settings),
+	}
+}
+
+// EffectiveConfig is the configuration the engine actually runs, after defaults are
+// applied and with secrets masked
+type EffectiveConfig struct {
+	Settings  ServerSettings            `json:"settings"`
+	Targets   []Target                  `json:"targets"`
+	Notifiers map[string]NotifierConfig `json:"notifiers"`
+}
+
+// GetEffectiveConfig resolves per-target and global defaults the way the engine
+// does and masks notifier credentials, header secrets and cookie values
+func (sm *StateManager) GetEffectiveConfig() EffectiveConfig {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	settings := sm.state.Settings
+	if settings.ShutdownTimeoutSeconds == 0 {
+		settings.ShutdownTimeoutSeconds = int(defaultShutdownTimeout / time.Second)
+	}
+	if settings.StatusReport.Enabled && settings.StatusReport.Interval == 0 {
+		settings.StatusReport.Interval = 60
+	}
+
+	targets := make([]Target, 0, len(sm.state.Targets))
+	for _, target := range sm.state.Targets {
+		targets = append(targets, effectiveTarget(target, settings))
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].URL < targets[j].URL })
+
+	notifiers := make(map[string]NotifierConfig, len(sm.state.Alerts))
+	for name, notifier := range sm.state.Alerts {
+		notifier.Settings = maskSecrets(notifier.Settings)
+		notifiers[name] = notifier
+	}
+
+	return EffectiveConfig{Settings: maskServerSettings(settings), Targets: targets, Notifiers: notifiers}
+}
+
+// CanonicalConfig is a stable-ordered export of the stored configuration for diffing:
+// lists are sorted by URL or name and runtime fields (version, timestamps) are omitted
+type CanonicalConfig struct {
+	Targets  []Target         `yaml:"targets"`
+	Alerts   []NotifierConfig `yaml:"alerts"`
+	Settings ServerSettings   `yaml:"settings"`
+	Hooks    []Hook           `yaml:"hooks"`
+}
+
+// GetCanonicalConfig returns the stored configuration as written (no defaults applied),
+// sorted for stable output, with secrets masked
+func (sm *StateManager) GetCanonicalConfig() CanonicalConfig {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	config := CanonicalConfig{
+		Targets:  make([]Target, 0, len(sm.state.Targets)),
+		Alerts:   make([]NotifierConfig, 0, len(sm.state.Alerts)),
+		Settings: maskServerSettings(sm.state.Settings),
+		Hooks:    make([]Hook, 0, len(sm.state.Hooks)),
+	}
+	for _, target := range sm.state.Targets {
+		config.Targets = append(config.Targets, maskTargetSecrets(target))
+	}
+	sort.Slice(config.Targets, func(i, j int) bool { return config.Targets[i].URL < config.Targets[j].URL })
+
+	for name, notifier := range sm.state.Alerts {
+		notifier.Name = name
+		notifier.Settings = maskSecrets(notifier.Settings)
+		config.Alerts = append(config.Alerts, notifier)
+	}
+	sort.Slice(config.Alerts, func(i, j int) bool { return config.Alerts[i].Name < config.Alerts[j].Name })
+
+	for name, hook := range sm.state.Hooks {
+		hook.Name = name
+		if hook.Auth.BearerToken != "" {
+			hook.Auth.BearerToken = maskedSecret
+		}
+		if hook.Auth.Password != "" {
+			hook.Auth.Password = maskedSecret
+		}
+		if hook.Auth.HMACSecret != "" {
+			hook.Auth.HMACSecret = maskedSecret
+		}
+		config.Hooks = append(config.Hooks, hook)
+	}
+	sort.Slice(config.Hooks, func(i, j int) bool { return config.Hooks[i].Name < config.Hooks[j].Name })
+
+	return config
+}
+
+// effectiveTarget applies the defaults the engine and HTTP check use for unset target fields
+// and masks the target's secrets
+func effectiveTarget(target Target, settings ServerSettings) Target {
+	if target.Method == "" {
+		target.Method = "GET"
+	}
+	if target.Threshold == 0 && target.DownAfterFailures == 0 {
+		target.Threshold = settings.DefaultThreshold
+		if target.Threshold == 0 {
+			target.Threshold = 30
+		}
+	}
+	if len(target.StatusCodes) == 0 {
+		target.StatusCodes = []string{"*"}
+	}
+	if target.CheckStrategy == "" {
+		target.CheckStrategy = "http"
+	}
+	// The engine prefers alerts, falls back to the legacy alert_strategy, then console
+	if len(target.Alerts) == 0 {
+		if target.AlertStrategy != "" {
+			target.Alerts = []string{target.AlertStrategy}
+		} else {
+			target.Alerts = []string{"console"}
+		}
+	}
+	target.AlertStrategy = ""
+	if target.CheckStrategy == "http" {
+		if target.MaxRedirects == 0 {
+			target.MaxRedirects = defaultMaxRedirects
+		}
+		target.Timeout = int(httpCheckTimeout(&target) / time.Second)
+		readLimit, storeLimit := bodyLimits(&target, settings.MaxBodyStoreKB)
+		target.MaxBodyReadKB, target.MaxBodyStoreKB = int(readLimit/1024), int(storeLimit/1024)
+		if target.CABundleFile == "" {
+			target.CABundleFile = settings.CABundleFile
+		}
+	}
+	if target.Interval == 0 {
+		target.Interval = settings.CheckInterval
+		if target.Interval == 0 {
+			target.Interval = int(defaultCheckInterval / time.Second)
+		}
+	}
+	if target.InitialGraceSeconds == 0 {
+		target.InitialGraceSeconds = settings.InitialGraceSeconds
+	}
+	if target.RecoveryCooldownSeconds == 0 {
+		target.RecoveryCooldownSeconds = settings.RecoveryCooldownSeconds
+	}
+	if target.RecoveryThreshold == 0 {
+		target.RecoveryThreshold = max(settings.RecoveryThreshold, 1)
+	}
+	if target.RequireAckForAutoresolve == nil {
+		requireAck := settings.RequireAckForAutoresolve
+		target.RequireAckForAutoresolve = &requireAck
+	}
+
+	return maskTargetSecrets(target)
+}
+
+// configBundleFormat identifies a file written by the export command
+const configBundleFormat = "quick_watch-bundle"
+
+// configBundleVersion is the bundle layout written by export; import migrates older
+// versions up to it and refuses newer ones
+const configBundleVersion = 1
+
+// ConfigBundle is a portable copy of the full configuration (targets, settings, alerts
+// and hooks) for moving a setup between machines
+type ConfigBundle struct {
+	Format        string                    `yaml:"format"`
+	BundleVersion int                       `yaml:"bundle_version"`
+	Exported      time.Time                 `yaml:"exported"`
+	Targets       map[string]Target         `yaml:"targets"`
+	Settings      ServerSettings            `yaml:"settings"`
+	Alerts        map[string]NotifierConfig `yaml:"alerts"`
+	Hooks         map[string]Hook           `yaml:"hooks"`
+}
+
+// ExportBundle encodes the stored configuration as a yaml or json bundle. Unlike the
+// canonical config, secrets are kept so the bundle can be imported elsewhere; values that
+// came from ${VAR} references are written as the references.
+func (sm *StateManager) ExportBundle(format string) ([]byte, error) {
+	sm.mutex.RLock()
+	bundle := ConfigBundle{
+		Format:        configBundleFormat,
+		BundleVersion: configBundleVersion,
+		Exported:      time.Now(),
+		Targets:       sm.state.Targets,
+		Settings:      sm.state.Settings,
+		Alerts:        make(map[string]NotifierConfig, len(sm.state.Alerts)),
+		Hooks:         make(map[string]Hook, len(sm.state.Hooks)),
+	}
+	for name, notifier := range sm.state.Alerts {
+		notifier.Name = name
+		bundle.Alerts[name] = notifier
+	}
+	for name, hook := range sm.state.Hooks {
+		hook.Name = name
+		bundle.Hooks[name] = hook
+	}
+	data, err := yaml.Marshal(bundle)
+	envRefs := sm.envRefs
+	sm.mutex.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %v", err)
+	}
+	if data, err = restoreEnvYAML(data, envRefs); err != nil {
+		return nil, fmt.Errorf("failed to restore environment references: %v", err)
+	}
+
+	switch format {
+	case "yaml":
+		return data, nil
+	case "json":
+		// Round-trip through YAML so JSON uses the same snake_case keys
+		var generic any
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return nil, fmt.Errorf("failed to encode bundle: %v", err)
+		}
+		jsonData, err := json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode bundle: %v", err)
+		}
+		return append(jsonData, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s (use yaml or json)", format)
 	}
 }
 
+// migrateConfigBundle checks that a decoded bundle came from export and upgrades it to
+// configBundleVersion
+func migrateConfigBundle(bundle *ConfigBundle) error {
+	if bundle.Format != configBundleFormat {
+		return fmt.Errorf("not a quick_watch bundle (format is %q, expected %q)", bundle.Format, configBundleFormat)
+	}
+	if bundle.BundleVersion < 1 {
+		return fmt.Errorf("bundle_version is missing")
+	}
+	if bundle.BundleVersion > configBundleVersion {
+		return fmt.Errorf("bundle_version %d is newer than this build supports (%d); upgrade quick_watch", bundle.BundleVersion, configBundleVersion)
+	}
+	// Version 1 is the only layout so far; later versions convert older bundles here,
+	// one version at a time
+	return nil
+}
+
+// ImportBundle reads a yaml or json bundle and, once its targets, settings, alerts and
+// hooks all validate, replaces the stored configuration with it. With merge, the bundle's
+// targets, alerts and hooks are added to (or overwrite) the stored ones by key and the
+// stored settings are kept.
+func (sm *StateManager) ImportBundle(data []byte, merge bool) (*ConfigBundle, error) {
+	expanded, envRefs, err := expandEnvYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand environment variables in bundle: %v", err)
+	}
+	var bundle ConfigBundle
+	if err := yaml.Unmarshal(expanded, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %v", err)
+	}
+	if err := migrateConfigBundle(&bundle); err != nil {
+		return nil, err
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	settings := bundle.Settings
+	var storedTargets map[string]Target
+	var storedAlerts map[string]NotifierConfig
+	var storedHooks map[string]Hook
+	if merge {
+		settings = sm.state.Settings
+		storedTargets, storedAlerts, storedHooks = sm.state.Targets, sm.state.Alerts, sm.state.Hooks
+	}
+	targets := mergeMaps(storedTargets, bundle.Targets)
+	alerts := mergeMaps(storedAlerts, bundle.Alerts)
+	hooks := mergeMaps(storedHooks, bundle.Hooks)
+
+	if err := validateTargets(targets, nil); err != nil {
+		return nil, fmt.Errorf("invalid targets: %v", err)
+	}
+	if err := validateSettings(settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %v", err)
+	}
+	if err := validateAlerts(alerts); err != nil {
+		return nil, fmt.Errorf("invalid alerts: %v", err)
+	}
+	for name, hook := range hooks {
+		if !strings.HasPrefix(hook.Path, "/") {
+			return nil, fmt.Errorf("invalid hooks: hook %s: path must start with /, got %q", name, hook.Path)
+		}
+	}
+
+	// Save the imported state before swapping it in, so a failed write leaves memory
+	// matching the file
+	next := *sm.state
+	next.Targets = targets
+	next.Settings = settings
+	next.Alerts = alerts
+	next.Hooks = hooks
+	if merge {
+		envRefs = mergeMaps(sm.envRefs, envRefs)
+	}
+	if err := sm.writeStateUnlocked(&next, envRefs); err != nil {
+		return nil, err
+	}
+	sm.state = &next
+	sm.envRefs = envRefs
+	return &bundle, nil
+}
+
+// mergeMaps returns a new map holding base overlaid with overlay
+func mergeMaps[K comparable, V any](base, overlay map[K]V) map[K]V {
+	merged := make(map[K]V, len(base)+len(overlay))
+	maps.Copy(merged, base)
+	maps.Copy(merged, overlay)
+	return merged
+}
+
 // GetAlerts returns all notifiers
 func (sm *StateManager) GetAlerts() map[string]NotifierConfig {
 	sm.mutex.RLock()
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Layouts of timestamps shown in alerts, the web UI and status reports
+const (
+	timestampLayout = "2006-01-02 15:04:05 MST"
+	clockLayout     = "15:04:05"
+)
+
+// displayLocation is the zone from settings.timezone, or nil for the server's local time
+var displayLocation atomic.Pointer[time.Location]
+
+// loadTimezone resolves a timezone setting; "" and "local" are the server's local time
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// configureTimezone applies settings.timezone to displayed timestamps and log lines
+func configureTimezone(settings ServerSettings) {
+	location, err := loadTimezone(settings.Timezone)
+	if err != nil {
+		log.Printf("Warning: invalid timezone %q: %v; using local time", settings.Timezone, err)
+		location = time.Local
+	}
+	if location == time.Local {
+		displayLocation.Store(nil)
+	} else {
+		displayLocation.Store(location)
+	}
+	configureLogTimestamps(location)
+}
+
+// inDisplayZone converts t to the configured timezone
+func inDisplayZone(t time.Time) time.Time {
+	if location := displayLocation.Load(); location != nil {
+		return t.In(location)
+	}
+	return t.Local()
+}
+
+// formatTimestamp formats t as a full timestamp in the configured timezone
+func formatTimestamp(t time.Time) string {
+	return inDisplayZone(t).Format(timestampLayout)
+}
+
+// formatClock formats t as a time of day in the configured timezone
+func formatClock(t time.Time) string {
+	return inDisplayZone(t).Format(clockLayout)
+}
+
+// displayTimezoneName is the IANA name the web UI formats times in, or "" to leave them
+// in the browser's zone when no timezone is configured
+func displayTimezoneName() string {
+	if location := displayLocation.Load(); location != nil {
+		return location.String()
+	}
+	return ""
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
+	"net/url"
 	"os"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	qc "github.com/bevelwork/quick_color"
@@ -32,6 +39,99 @@ type Target struct {
 	Alerts []string `json:"alerts" yaml:"alerts,omitempty"`
 	// Legacy single alert strategy name (kept for backward compatibility)
 	AlertStrategy string `json:"alert_strategy,omitempty" yaml:"alert_strategy,omitempty"`
+	// For HTTP: PEM CA bundle trusted in addition to system roots (overrides settings.ca_bundle_file)
+	CABundleFile string `json:"ca_bundle_file,omitempty" yaml:"ca_bundle_file,omitempty"`
+	// For HTTP: PEM client certificate and key presented to servers requiring mutual TLS (set both)
+	ClientCertFile string `json:"client_cert_file,omitempty" yaml:"client_cert_file,omitempty"`
+	ClientKeyFile  string `json:"client_key_file,omitempty" yaml:"client_key_file,omitempty"`
+	// For HTTP and gRPC over TLS: skip TLS certificate verification (self-signed test endpoints only; flagged in logs and UI)
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty"`
+	// For gRPC: service name sent in the health check request (default: "" = the whole server)
+	GRPCService string `json:"grpc_service,omitempty" yaml:"grpc_service,omitempty"`
+	// For gRPC: dial with TLS instead of plaintext (h2c)
+	GRPCTLS bool `json:"grpc_tls,omitempty" yaml:"grpc_tls,omitempty"`
+	// For gRPC: "service" (default) checks grpc_service; "reflection" looks the health service up via server reflection and falls back to a connectivity check without one
+	GRPCDiscovery string `json:"grpc_discovery,omitempty" yaml:"grpc_discovery,omitempty"`
+	// For ping: echo requests sent per check (default: 3, max 10)
+	PingCount int `json:"ping_count,omitempty" yaml:"ping_count,omitempty"`
+	// For ping: packet loss percentage above which the check fails (default: 0, any loss fails)
+	MaxPacketLoss float64 `json:"max_packet_loss,omitempty" yaml:"max_packet_loss,omitempty"`
+	// For HTTP: redirects followed before the check fails with "too many redirects" (default: 10)
+	MaxRedirects int `json:"max_redirects,omitempty" yaml:"max_redirects,omitempty"`
+	// For HTTP: false stops at the first response so a 3xx is judged against status_codes (default: true)
+	FollowRedirects *bool `json:"follow_redirects,omitempty" yaml:"follow_redirects,omitempty"`
+	// For HTTP: content the response body must contain; "/pattern/" is a regex (only the first max_body_read_kb is searched)
+	BodyMatch string `json:"body_match,omitempty" yaml:"body_match,omitempty"`
+	// For HTTP and gRPC: seconds a check may take before it fails with a client-side timeout (default: 10)
+	Timeout int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Deprecated: alias of Retries, mapped onto it when the target is loaded
+	RetryOnFailure int `json:"retry_on_failure,omitempty" yaml:"retry_on_failure,omitempty"`
+	// For HTTP: per-phase latency limits in ms (dns, connect, tls, ttfb); exceeding one fails the check
+	PhaseThresholds map[string]int `json:"phase_thresholds,omitempty" yaml:"phase_thresholds,omitempty"`
+	// Response time in ms above which a successful check is SLOW (degraded, alerted separately from DOWN)
+	MaxResponseTime int `json:"max_response_time,omitempty" yaml:"max_response_time,omitempty"`
+	// Alert names that receive SLOW alerts (default: the target's alerts)
+	SlowAlerts []string `json:"slow_alerts,omitempty" yaml:"slow_alerts,omitempty"`
+	// Alert names per event type (down, recovery, slow, size); types left out use alerts
+	AlertRoutes map[string][]string `json:"alert_routes,omitempty" yaml:"alert_routes,omitempty"`
+	// For HTTP: KB of response body read and inspected, and KB kept in history (both default: 10)
+	MaxBodyReadKB  int `json:"max_body_read_kb,omitempty" yaml:"max_body_read_kb,omitempty"`
+	MaxBodyStoreKB int `json:"max_body_store_kb,omitempty" yaml:"max_body_store_kb,omitempty"`
+	// For HTTP: named JSON paths (e.g. error_code: "$.error.code") extracted from the response body
+	Extract map[string]string `json:"extract,omitempty" yaml:"extract,omitempty"`
+	// For HTTP: values the JSON response body must hold (e.g. path: "$.status", equals: "ok"); a mismatch fails the check
+	JSONAssertions []JSONAssertion `json:"json_assertions,omitempty" yaml:"json_assertions,omitempty"`
+	// Paging severity: "critical", "warning" or "info"; only critical targets page in critical-only mode
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Free-form labels (e.g. team or service group) used to filter the dashboard, /api/status and status reports
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Names of targets this one depends on; its DOWN alerts are suppressed while any of them is down
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	// For HTTP: request body sent with each check (e.g. a POST payload); Content-Type comes from headers,
+	// defaulting to application/json for a JSON body and text/plain otherwise
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+	// For HTTP: cookies sent with each check; values may reference env vars as ${VAR}
+	Cookies map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty"`
+	// Go text/template replacing the default DOWN alert text; has .Target, .Result and .Extracted (e.g. "{{.Extracted.error_code}}")
+	AlertMessageTemplate string `json:"alert_message_template,omitempty" yaml:"alert_message_template,omitempty"`
+	// Seconds a never-healthy target may fail before its first DOWN alert (overrides settings.initial_grace_seconds)
+	InitialGraceSeconds int `json:"initial_grace_seconds,omitempty" yaml:"initial_grace_seconds,omitempty"`
+	// Seconds after an ALL CLEAR during which a new failure's DOWN alert is held back (overrides settings.recovery_cooldown_seconds)
+	RecoveryCooldownSeconds int `json:"recovery_cooldown_seconds,omitempty" yaml:"recovery_cooldown_seconds,omitempty"`
+	// Stop checking and alerting on this target while keeping its config and history (see pause/resume)
+	Paused bool `json:"paused,omitempty" yaml:"paused,omitempty"`
+	// Consecutive successful checks needed before a down target recovers, overriding settings recovery_threshold (default: 1)
+	RecoveryThreshold int `json:"recovery_threshold,omitempty" yaml:"recovery_threshold,omitempty"`
+	// Checks kept in this target's history, overriding settings check_history_size (default: 1000)
+	CheckHistorySize int `json:"check_history_size,omitempty" yaml:"check_history_size,omitempty"`
+	// Seconds between checks of this target (overrides settings.check_interval)
+	Interval int `json:"interval,omitempty" yaml:"interval,omitempty"`
+	// Re-checks (max 5, backing off from 250ms) within one cycle before a failure is recorded
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+	// Overrides settings.require_ack_for_autoresolve for this target when set
+	RequireAckForAutoresolve *bool `json:"require_ack_for_autoresolve,omitempty" yaml:"require_ack_for_autoresolve,omitempty"`
+	// For HTTP: alert when the response body's content changes, even if its size does not
+	ContentAlerts *ContentAlertConfig `json:"content_alerts,omitempty" yaml:"content_alerts,omitempty"`
+	// Consecutive failed checks before the target is down, instead of threshold seconds (e.g. 3)
+	DownAfterFailures int `json:"failure_count,omitempty" yaml:"failure_count,omitempty"`
+	// Stages that page more alerts while a DOWN incident stays unacknowledged (overrides settings.escalation)
+	Escalation []EscalationStage `json:"escalation,omitempty" yaml:"escalation,omitempty"`
+	// For HTTP, TCP and gRPC: "4" or "6" dials only that IP version; "auto" or empty uses either
+	IPVersion string `json:"ip_version,omitempty" yaml:"ip_version,omitempty"`
+	// Slack channel ("#name" or ID) this target's messages post to, overriding the notifier's channel
+	SlackChannel string `json:"slack_channel,omitempty" yaml:"slack_channel,omitempty"`
+	// Slack mention prefixed to this target's DOWN alerts: "here", "channel", "everyone" or a user ID
+	SlackMention string `json:"slack_mention,omitempty" yaml:"slack_mention,omitempty"`
+	// For HTTP: Content-Type the response must start with (e.g. "application/json"); anything else fails the check
+	ExpectedContentType string `json:"expected_content_type,omitempty" yaml:"expected_content_type,omitempty"`
+	// For webhook: request sent when the target is triggered and when it recovers
+	Callback *WebhookCallback `json:"callback,omitempty" yaml:"callback,omitempty"`
+	// Backends checked each cycle in place of url (e.g. hosts behind a load balancer); url stays the target's identity
+	URLs []string `json:"urls,omitempty" yaml:"urls,omitempty"`
+	// How backend results combine: "all_down" (default), "any_down" or "quorum"
+	BackendPolicy string `json:"backend_policy,omitempty" yaml:"backend_policy,omitempty"`
+	// For backend_policy quorum: healthy backends needed to stay up (default: a majority)
+	BackendQuorum int `json:"backend_quorum,omitempty" yaml:"backend_quorum,omitempty"`
 }
 
 // SizeAlertConfig represents configuration for page size change detection
@@ -41,6 +141,19 @@ type SizeAlertConfig struct {
 	Threshold   float64 `json:"threshold" yaml:"threshold"`       // Percentage change threshold (default: 0.5 = 50%)
 }
 
+// ContentAlertConfig represents configuration for response content change detection
+type ContentAlertConfig struct {
+	Enabled     bool `json:"enabled" yaml:"enabled"`                               // Hash each response body (up to max_body_read_kb) and alert when it changes
+	HistorySize int  `json:"history_size,omitempty" yaml:"history_size,omitempty"` // Number of recent hashes a new body is compared against (default: 10)
+}
+
+// EscalationStage pages extra alerts once a DOWN incident has gone unacknowledged for
+// AfterMinutes since its first alert
+type EscalationStage struct {
+	AfterMinutes int      `json:"after_minutes" yaml:"after_minutes"` // minutes after the first DOWN alert
+	Alerts       []string `json:"alerts" yaml:"alerts"`               // alert names paged at this stage
+}
+
 // TargetConfig represents the configuration for targets
 type TargetConfig struct {
 	Targets    []Target       `json:"targets"`
@@ -72,6 +185,12 @@ type Hook struct {
 	Metadata map[string]string `json:"metadata" yaml:"metadata,omitempty"`
 }
 
+// JSONAssertion requires the value at a JSON path of an HTTP response body to equal Equals
+type JSONAssertion struct {
+	Path   string `json:"path" yaml:"path"`
+	Equals any    `json:"equals" yaml:"equals"`
+}
+
 // HookAuth defines optional authentication for a hook route
 type HookAuth struct {
 	// If set, require Authorization: Bearer <Token>
@@ -79,6 +198,10 @@ type HookAuth struct {
 	// If set, require HTTP Basic Auth
 	Username string `json:"username" yaml:"username,omitempty"`
 	Password string `json:"password" yaml:"password,omitempty"`
+	// Hooks only: if set, require an HMAC-SHA256 of the request body under this secret
+	HMACSecret string `json:"hmac_secret" yaml:"hmac_secret,omitempty"`
+	// Hooks only: header carrying the signature (default: X-Hub-Signature-256)
+	SignatureHeader string `json:"signature_header" yaml:"signature_header,omitempty"`
 }
 
 // NotifierConfig represents a notification configuration
@@ -92,7 +215,7 @@ type NotifierConfig struct {
 
 // ConsoleNotifierSettings represents console notifier settings
 type ConsoleNotifierSettings struct {
-	Style string `json:"style" yaml:"style"`                   // "plain" or "stylized"
+	Style string `json:"style" yaml:"style"`                   // "plain", "stylized" or "compact"
 	Color bool   `json:"color" yaml:"color"`                   // enable/disable colors
 	Bold  bool   `json:"bold,omitempty" yaml:"bold,omitempty"` // optional explicit bold toggle
 }
@@ -126,11 +249,17 @@ type CheckHistoryEntry struct {
 	AlertCount       int // Number of alerts sent for this failure sequence
 	WasAcked         bool
 	WasRecovered     bool
-	ContentType      string  // Content-Type header value
-	ResponseBody     string  // Response body (limited to first 10KB for JSON responses)
-	VisualDifference float64 // For page-comparison: percentage difference (0.0-100.0)
-	ScreenshotPath   string  // For page-comparison: path to current screenshot
-	DiffImagePath    string  // For page-comparison: path to diff image
+	ContentType      string       // Content-Type header value
+	ResponseBody     string       // Response body (JSON only unless capture_all_bodies, up to max_body_store_kb)
+	VisualDifference float64      // For page-comparison: percentage difference (0.0-100.0)
+	ScreenshotPath   string       // For page-comparison: path to current screenshot
+	DiffImagePath    string       // For page-comparison: path to diff image
+	SuppressedBy     string       // Name of the down dependency that suppressed this check's alert
+	Timings          *HTTPTimings // For HTTP: DNS, connect, TLS and TTFB breakdown
+	Slow             bool         // Succeeded but exceeded the target's max_response_time
+
+	// For targets with urls: each backend's outcome of this check
+	Backends []BackendResult
 }
 
 // TargetState represents the current state of a target
@@ -139,11 +268,13 @@ type TargetState struct {
 	IsDown                 bool
 	DownSince              *time.Time
 	LastCheck              *CheckResult
+	LastFailure            *CheckResult // Most recent failed check, kept after recovery (see Diagnose)
 	CheckStrategy          CheckStrategy
 	AlertStrategies        []AlertStrategy
-	SizeHistory            []int64 // Track response sizes for change detection
-	CurrentAckToken        string  // Current acknowledgement token for active alert
-	AcknowledgedBy         string  // Who acknowledged (from request metadata)
+	SizeHistory            []int64  // Track response sizes for change detection
+	ContentHashHistory     []string // Track response body hashes for content change detection
+	CurrentAckToken        string   // Current acknowledgement token for active alert
+	AcknowledgedBy         string   // Who acknowledged (from request metadata)
 	AcknowledgedAt         *time.Time
 	AcknowledgementNote    string              // Optional note from acknowledger
 	AcknowledgementContact string              // Contact information (Slack, Zoom, phone, etc.)
@@ -151,8 +282,31 @@ type TargetState struct {
 	RecoveryTime           *time.Time          // When auto-recovery is scheduled
 	FailureCount           int                 // Number of consecutive failures
 	LastAlertTime          *time.Time          // Time of the last alert sent
-	CheckHistory           []CheckHistoryEntry // Running history of checks (max 1000 entries)
+	FirstAlertTime         *time.Time          // Time of the incident's first DOWN alert; escalation delays count from here
+	EscalationStage        int                 // Escalation stages already paged for the current incident
+	CheckHistory           []CheckHistoryEntry // Running history of checks (at most HistorySize entries)
+	HistorySize            int                 // Most entries kept in CheckHistory (0 = defaultCheckHistorySize)
+	HistoryMaxAge          time.Duration       // Entries older than this are pruned (0 = count cap only)
+	FirstCheckAt           *time.Time          // When the first check of this target ran
+	HasSucceeded           bool                // Whether any check has succeeded since the target was added
+	SuppressedDependents   []string            // Dependents whose alerts are suppressed by this target's current outage
+	PendingDependents      []string            // Suppressed dependents not yet covered by a "dependency down" note
+	SlowSince              *time.Time          // When successful checks started exceeding max_response_time
+	SlowAlertSent          bool                // Whether a SLOW alert went out for the current slow period
+	StateTransitions       []time.Time         // Recent up/down changes within the flap detection window
+	FlappingSince          *time.Time          // When the target started flapping (nil while stable)
+	RecoverySuccesses      int                 // Consecutive successes while down, counted towards recovery_threshold
+	FailedChecks           int                 // Consecutive failed checks, counted towards the target's failure_count
+	RecoveredAt            *time.Time          // When the last incident's ALL CLEAR went out; recovery_cooldown_seconds counts from here
+	checkNow               chan struct{}       // Signals targetLoop to check immediately (see TriggerCheck)
+	stopLoop               context.CancelFunc  // Stops this target's loop (see Reload)
+	loopDone               chan struct{}       // Closed when this target's loop has returned
 	historyMutex           sync.RWMutex        // Protects CheckHistory
+	historyStore           *sqliteStore        // Also records CheckHistory when state is kept in SQLite (nil otherwise)
+	dependentsMutex        sync.Mutex          // Protects SuppressedDependents and PendingDependents
+
+	// checkRequests carries on-demand checks to targetLoop, which replies with the result (see CheckNow)
+	checkRequests chan chan *CheckResult
 }
 
 // TargetEngine represents the core targeting engine
@@ -175,18 +329,72 @@ type StatusMetrics struct {
 	ResolvedOutages   []ResolvedOutage
 	LastReportTime    time.Time
 	mutex             sync.RWMutex
+
+	// Totals since start; unlike the fields above they are not reset by status reports
+	AlertsSentTotal        int
+	NotificationsSentTotal int
+}
+
+// DurationHistogram is a minimal cumulative histogram of durations in seconds,
+// shaped for the Prometheus text exposition format
+type DurationHistogram struct {
+	Buckets []float64 // upper bounds in seconds, ascending
+	counts  []uint64  // observations per bucket (non-cumulative)
+	count   uint64
+	sum     float64
+	mutex   sync.Mutex
+}
+
+// NewDurationHistogram creates a histogram with the given bucket upper bounds (seconds)
+func NewDurationHistogram(buckets []float64) *DurationHistogram {
+	return &DurationHistogram{
+		Buckets: buckets,
+		counts:  make([]uint64, len(buckets)),
+	}
+}
+
+// Observe records a single duration
+func (h *DurationHistogram) Observe(d time.Duration) {
+	seconds := d.Seconds()
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.count++
+	h.sum += seconds
+	for i, upper := range h.Buckets {
+		if seconds <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+}
+
+// Snapshot returns cumulative bucket counts along with the total count and sum
+func (h *DurationHistogram) Snapshot() (cumulative []uint64, count uint64, sum float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	cumulative = make([]uint64, len(h.counts))
+	var running uint64
+	for i, c := range h.counts {
+		running += c
+		cumulative[i] = running
+	}
+	return cumulative, h.count, h.sum
 }
 
 // ResolvedOutage represents an outage that was resolved
 type ResolvedOutage struct {
 	TargetName   string
+	TargetTags   []string // Target tags at the time, for tag-scoped status reports
 	ResolvedAt   time.Time
 	DownDuration time.Duration
+	Acknowledged bool // Whether the incident was acknowledged before it recovered
 }
 
 type TargetEngine struct {
 	targets                []*TargetState
 	config                 *TargetConfig
+	targetsMutex           sync.RWMutex // Protects targets and config, which Reload replaces
+	reloadMutex            sync.Mutex   // Serializes Reload calls
 	checkStrategies        map[string]CheckStrategy
 	alertStrategies        map[string]AlertStrategy
 	notificationStrategies map[string]NotificationStrategy
@@ -195,7 +403,22 @@ type TargetEngine struct {
 	ackMutex               sync.RWMutex            // Protects ackTokenMap and hookAckTokenMap
 	serverAddress          string                  // Server address for generating acknowledgement URLs
 	acksEnabled            bool                    // Whether acknowledgements are enabled
+	settings               ServerSettings          // Global settings snapshot (zero value when no state manager)
 	metrics                *StatusMetrics          // Metrics for status reports
+	checkDurations         *DurationHistogram      // Wall-clock time spent executing each check cycle
+	cancel                 context.CancelFunc      // Stops the target loops started by Start
+	runCtx                 context.Context         // Context target loops run under; nil until Start
+	loops                  sync.WaitGroup          // Tracks running target loops
+	otlp                   *OTLPExporter           // Optional OTLP exporter (settings.otlp_enabled)
+	criticalOnly           atomic.Bool             // When set, only severity=critical targets page
+	events                 *EventBroadcaster       // Live check results for /api/events
+	checkLimiter           *CheckLimiter           // Bounds concurrent checks (settings.max_concurrent_checks)
+	incidents              *IncidentLog            // Outages from first alertable failure to recovery (/api/incidents)
+	historyStore           *sqliteStore            // Durable check history for SQLite state (nil for YAML state)
+	notifierHealth         *notifierHealthTracker  // Consecutive send failures per notifier (/api/status)
+
+	// Notifier active_hours/quiet_hours, keyed by the alert strategy built from the notifier
+	notifierSchedules map[AlertStrategy]*NotifierSchedule
 }
 
 // NewTargetEngine creates a new targeting engine
@@ -207,10 +430,26 @@ func NewTargetEngine(config *TargetConfig, stateManager *StateManager) *TargetEn
 		notificationStrategies: make(map[string]NotificationStrategy),
 		ackTokenMap:            make(map[string]*TargetState),
 		hookAckTokenMap:        make(map[string]*HookState),
+		notifierSchedules:      make(map[AlertStrategy]*NotifierSchedule),
 		metrics: &StatusMetrics{
 			LastReportTime:  time.Now(),
 			ResolvedOutages: make([]ResolvedOutage, 0),
 		},
+		checkDurations: NewDurationHistogram([]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}),
+		events:         NewEventBroadcaster(),
+	}
+
+	if stateManager != nil {
+		engine.settings = stateManager.GetSettings()
+		engine.incidents = NewIncidentLog(incidentLogPath(stateManager.filePath))
+		engine.historyStore = stateManager.historyStore()
+	} else {
+		engine.incidents = NewIncidentLog("")
+	}
+	engine.checkLimiter = NewCheckLimiter(engine.settings.MaxConcurrentChecks)
+	engine.notifierHealth = newNotifierHealthTracker(engine.settings.NotifierFailureThreshold)
+	if engine.settings.OTLPEnabled && engine.settings.OTLPEndpoint != "" {
+		engine.otlp = NewOTLPExporter(engine.settings.OTLPEndpoint)
 	}
 
 	// Register default strategies
@@ -222,13 +461,41 @@ func NewTargetEngine(config *TargetConfig, stateManager *StateManager) *TargetEn
 	return engine
 }
 
+// hookNotifierTypes are the notifier types that register a notification strategy for hooks
+var hookNotifierTypes = map[string]bool{"console": true, "slack": true, "email": true}
+
+// unknownHookAlerts returns the hook's alert names that known does not resolve
+func unknownHookAlerts(hook Hook, known func(name string) bool) []string {
+	var unknown []string
+	for _, name := range hook.Alerts {
+		if !known(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// newCheckStrategies builds the built-in check strategies, keyed by check_strategy name
+func newCheckStrategies(settings ServerSettings) map[string]CheckStrategy {
+	httpStrategy := NewHTTPCheckStrategyWithDefaults(settings.CABundleFile, settings.DefaultHeaders)
+	httpStrategy.bodyStoreKB = settings.MaxBodyStoreKB
+	httpStrategy.captureAll = settings.CaptureAllBodies
+	return map[string]CheckStrategy{
+		"http":            httpStrategy,
+		"webhook":         NewWebhookCheckStrategy(),
+		"tcp":             NewTCPCheckStrategy(),
+		"grpc":            NewGRPCCheckStrategy(),
+		"ping":            NewPingCheckStrategy(),
+		"page-comparison": NewPageComparisonCheckStrategy(),
+	}
+}
+
 // registerDefaultStrategies registers the default strategies
 func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 	// Check strategies
-	e.checkStrategies["http"] = NewHTTPCheckStrategy()
-	e.checkStrategies["webhook"] = NewWebhookCheckStrategy()
-	e.checkStrategies["tcp"] = NewTCPCheckStrategy()
-	e.checkStrategies["page-comparison"] = NewPageComparisonCheckStrategy()
+	for name, strategy := range newCheckStrategies(e.settings) {
+		e.checkStrategies[name] = strategy
+	}
 
 	// Alert strategies - register default console (stylized + color)
 	e.alertStrategies["console"] = NewConsoleAlertStrategy()
@@ -240,19 +507,38 @@ func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 			if notifier.Enabled {
 				switch notifier.Type {
 				case "slack":
-					if webhookURL, ok := notifier.Settings["webhook_url"].(string); ok && webhookURL != "" {
+					if tokenEnv, ok := notifier.Settings["bot_token_env"].(string); ok && tokenEnv != "" {
+						// Bot token mode: chat.postMessage to this notifier's channel (alerts only, not hooks)
+						token, err := slackBotTokenFromSettings(notifier.Settings)
+						if err != nil {
+							fmt.Printf("%s slack notifier '%s' %v\n", qc.Colorize("❌ Error:", qc.ColorRed), name, err)
+							os.Exit(1)
+						}
+						channel, _ := notifier.Settings["channel"].(string)
+						debug, _ := notifier.Settings["debug"].(bool)
+						slack := NewSlackBotAlertStrategy(token, channel, debug)
+						slack.maxMessageLength = notifierMaxMessageLength(notifier.Settings, defaultSlackMaxMessageLength)
+						slack.username, _ = notifier.Settings["username"].(string)
+						slack.iconEmoji, _ = notifier.Settings["icon_emoji"].(string)
+						e.alertStrategies[name] = slack
+					} else if webhookURL, ok := notifier.Settings["webhook_url"].(string); ok && webhookURL != "" {
 						debug := false
 						if d, ok := notifier.Settings["debug"].(bool); ok {
 							debug = d
 						}
-						e.alertStrategies[name] = NewSlackAlertStrategyWithDebug(webhookURL, debug)
+						slack := NewSlackAlertStrategyWithDebug(webhookURL, debug)
+						slack.maxMessageLength = notifierMaxMessageLength(notifier.Settings, defaultSlackMaxMessageLength)
+						slack.channel, _ = notifier.Settings["channel"].(string)
+						slack.username, _ = notifier.Settings["username"].(string)
+						slack.iconEmoji, _ = notifier.Settings["icon_emoji"].(string)
+						e.alertStrategies[name] = slack
 						// Register a notification strategy with the same name for hooks
 						e.notificationStrategies[name] = NewSlackNotificationStrategy(webhookURL)
 					}
 				case "email":
-					// expected settings: smtp_host, smtp_port, username, password_env, to, debug (optional)
+					// expected settings: smtp_host, smtp_port, username, password_env, to, cc/bcc (optional), tls_mode/skip_verify (optional), debug (optional)
 					host, _ := notifier.Settings["smtp_host"].(string)
-					to, _ := notifier.Settings["to"].(string)
+					recipients := emailRecipientsFromSettings(notifier.Settings)
 					username, _ := notifier.Settings["username"].(string)
 					passwordEnv, _ := notifier.Settings["password_env"].(string)
 					debug := false
@@ -265,14 +551,21 @@ func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 					} else if vf, ok := notifier.Settings["smtp_port"].(float64); ok {
 						port = int(vf)
 					}
-					if strings.TrimSpace(host) != "" && port > 0 && strings.TrimSpace(username) != "" && strings.TrimSpace(to) != "" && strings.TrimSpace(passwordEnv) != "" {
+					if strings.TrimSpace(host) != "" && port > 0 && strings.TrimSpace(username) != "" && len(recipients.to) > 0 && strings.TrimSpace(passwordEnv) != "" {
 						pwd := os.Getenv(passwordEnv)
 						if strings.TrimSpace(pwd) == "" {
 							fmt.Printf("%s email notifier '%s' requires env %s to be set\n", qc.Colorize("❌ Error:", qc.ColorRed), name, passwordEnv)
 							os.Exit(1)
 						}
-						e.alertStrategies[name] = NewEmailAlertStrategyWithDebug(host, port, username, pwd, to, debug)
-						e.notificationStrategies[name] = NewEmailNotificationStrategy(host, port, username, pwd, to)
+						email := NewEmailAlertStrategyWithDebug(host, port, username, pwd, "", debug)
+						email.recipients = recipients
+						email.tls = smtpTLSConfigFromSettings(notifier.Settings)
+						email.maxMessageLength = notifierMaxMessageLength(notifier.Settings, defaultEmailMaxMessageLength)
+						e.alertStrategies[name] = email
+						emailNotifier := NewEmailNotificationStrategy(host, port, username, pwd, "")
+						emailNotifier.recipients = recipients
+						emailNotifier.tls = email.tls
+						e.notificationStrategies[name] = emailNotifier
 					}
 				case "file":
 					// expected settings: file_path (string), debug (optional bool), max_size_before_compress (optional int/float in MB)
@@ -302,6 +595,40 @@ func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 							e.alertStrategies[name] = NewFileAlertStrategyWithDebug(filePath, debug)
 						}
 					}
+				case "webhook":
+					// expected settings: webhook_url, auth (optional: bearer_token_env, or username + password_env),
+					// body_template (optional Go template), headers (optional map),
+					// client_cert_file/client_key_file, ca_bundle_file and skip_verify (optional TLS)
+					webhookURL, _ := notifier.Settings["webhook_url"].(string)
+					if strings.TrimSpace(webhookURL) != "" {
+						auth, err := webhookAuthFromSettings(notifier.Settings)
+						if err != nil {
+							fmt.Printf("%s webhook notifier '%s' %v\n", qc.Colorize("❌ Error:", qc.ColorRed), name, err)
+							os.Exit(1)
+						}
+						webhook := NewWebhookAlertStrategyWithAuth(webhookURL, auth)
+						webhook.maxMessageLength = notifierMaxMessageLength(notifier.Settings, defaultWebhookMaxMessageLength)
+						if webhook.bodyTemplate, err = parseWebhookBodyTemplate(notifier.Settings); err != nil {
+							fmt.Printf("%s webhook notifier '%s' %v\n", qc.Colorize("❌ Error:", qc.ColorRed), name, err)
+							os.Exit(1)
+						}
+						if webhook.headers, err = webhookHeadersFromSettings(notifier.Settings); err != nil {
+							fmt.Printf("%s webhook notifier '%s' %v\n", qc.Colorize("❌ Error:", qc.ColorRed), name, err)
+							os.Exit(1)
+						}
+						tlsConfig, err := webhookTLSConfigFromSettings(notifier.Settings)
+						if err != nil {
+							fmt.Printf("%s webhook notifier '%s' %v\n", qc.Colorize("❌ Error:", qc.ColorRed), name, err)
+							os.Exit(1)
+						}
+						if tlsConfig != nil {
+							if tlsConfig.InsecureSkipVerify {
+								log.Printf("⚠️  WARNING: TLS certificate verification is DISABLED for webhook notifier %s (%s) - skip_verify is set", name, maskSecretValue(webhookURL))
+							}
+							webhook.client.Transport = newTLSTransport(tlsConfig)
+						}
+						e.alertStrategies[name] = webhook
+					}
 				case "console":
 					// Respect console notifier settings (style/color)
 					style := "stylized"
@@ -315,6 +642,15 @@ func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 					e.alertStrategies[name] = NewConsoleAlertStrategyWithSettings(style, color)
 					e.notificationStrategies[name] = NewConsoleNotificationStrategy()
 				}
+				if strategy, exists := e.alertStrategies[name]; exists {
+					fallback, _ := notifier.Settings["fallback_alert"].(string)
+					e.notifierHealth.track(strategy, name, fallback)
+					if schedule, err := parseNotifierSchedule(notifier.Settings); err != nil {
+						log.Printf("Warning: notifier %s schedule ignored: %v", name, err)
+					} else if schedule != nil {
+						e.notifierSchedules[strategy] = schedule
+					}
+				}
 			}
 		}
 	}
@@ -338,50 +674,231 @@ func (e *TargetEngine) registerDefaultStrategies(stateManager *StateManager) {
 // initializeTargets initializes targets from configuration
 func (e *TargetEngine) initializeTargets() {
 	for _, target := range e.config.Targets {
-		state := &TargetState{
-			Target: &target,
-			IsDown: false,
-		}
+		e.targets = append(e.targets, e.newTargetState(target))
+	}
+}
 
-		// Set check strategy
-		if strategy, exists := e.checkStrategies[target.CheckStrategy]; exists {
-			state.CheckStrategy = strategy
-		} else {
-			state.CheckStrategy = e.checkStrategies["http"] // default
+// newTargetState builds the runtime state for target with its check and alert strategies resolved
+func (e *TargetEngine) newTargetState(target Target) *TargetState {
+	state := &TargetState{
+		Target:        &target,
+		IsDown:        false,
+		HistorySize:   e.settings.CheckHistorySize,
+		HistoryMaxAge: time.Duration(e.settings.HistoryRetentionHours) * time.Hour,
+		checkNow:      make(chan struct{}, 1),
+		checkRequests: make(chan chan *CheckResult),
+	}
+	if target.CheckHistorySize > 0 {
+		state.HistorySize = target.CheckHistorySize
+	}
+	if e.historyStore != nil {
+		// Pick up the history recorded before the last restart
+		state.historyStore = e.historyStore
+		var since time.Time
+		if state.HistoryMaxAge > 0 {
+			since = time.Now().Add(-state.HistoryMaxAge)
+		}
+		history, err := e.historyStore.CheckHistory(target.URL, since, time.Time{}, state.HistoryLimit())
+		if err != nil {
+			log.Printf("Warning: failed to load check history for %s: %v", target.Name, err)
 		}
+		state.CheckHistory = history
+	}
 
-		// Set alert strategies (supports multiple). Prefer new Alerts slice, fallback to legacy AlertStrategy.
-		strategyNames := target.Alerts
-		if len(strategyNames) == 0 {
-			if target.AlertStrategy != "" {
-				strategyNames = []string{target.AlertStrategy}
-			} else {
-				strategyNames = []string{"console"}
-			}
+	// Set check strategy
+	if strategy, exists := e.checkStrategies[target.CheckStrategy]; exists {
+		state.CheckStrategy = strategy
+	} else {
+		state.CheckStrategy = e.checkStrategies["http"] // default
+	}
+
+	// Set alert strategies (supports multiple). Prefer new Alerts slice, fallback to legacy AlertStrategy.
+	strategyNames := target.Alerts
+	if len(strategyNames) == 0 {
+		if target.AlertStrategy != "" {
+			strategyNames = []string{target.AlertStrategy}
+		} else {
+			strategyNames = []string{"console"}
 		}
-		for _, name := range strategyNames {
-			if strategy, exists := e.alertStrategies[name]; exists {
-				state.AlertStrategies = append(state.AlertStrategies, strategy)
-			}
+	}
+	for _, name := range strategyNames {
+		if strategy, exists := e.alertStrategies[name]; exists {
+			state.AlertStrategies = append(state.AlertStrategies, strategy)
 		}
-
-		e.targets = append(e.targets, state)
 	}
+	return state
 }
 
 // Start begins targeting all configured targets
 func (e *TargetEngine) Start(ctx context.Context) error {
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.runCtx = ctx
+
 	// Start targeting loop for each target
-	for _, state := range e.targets {
-		go e.targetLoop(ctx, state)
+	for _, state := range e.targetStates() {
+		e.startTargetLoop(state)
+	}
+	// Targets removed or renamed while the server was stopped leave no loop to resolve
+	// their incidents
+	e.resolveRemovedIncidents()
+
+	if e.otlp != nil {
+		e.loops.Add(1)
+		go func() {
+			defer e.loops.Done()
+			e.otlp.Run(ctx, e)
+		}()
 	}
 
 	return nil
 }
 
+// Stop cancels all target loops and pending auto-recovery timers, waiting for
+// in-flight checks to finish until ctx expires
+func (e *TargetEngine) Stop(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	for _, state := range e.targetStates() {
+		if state.RecoveryTimer != nil {
+			state.RecoveryTimer.Stop()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.loops.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for checks to finish: %v", ctx.Err())
+	}
+}
+
+// startTargetLoop runs state's loop under the engine's context with its own cancel,
+// so Reload can stop one target without touching the others
+func (e *TargetEngine) startTargetLoop(state *TargetState) {
+	ctx, cancel := context.WithCancel(e.runCtx)
+	state.stopLoop = cancel
+	state.loopDone = make(chan struct{})
+	e.loops.Add(1)
+	go func(done chan struct{}) {
+		defer e.loops.Done()
+		defer close(done)
+		e.targetLoop(ctx, state)
+	}(state.loopDone)
+}
+
+// stopTargetLoop stops state's loop and auto-recovery timer, waiting for an in-flight
+// check to finish until ctx expires
+func (e *TargetEngine) stopTargetLoop(ctx context.Context, state *TargetState) error {
+	if state.RecoveryTimer != nil {
+		state.RecoveryTimer.Stop()
+	}
+	if state.stopLoop == nil {
+		return nil
+	}
+	state.stopLoop()
+	select {
+	case <-state.loopDone:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for %s to finish its check: %v", state.Target.Name, ctx.Err())
+	}
+}
+
+// Reload applies config to a running engine without restarting it. Targets whose
+// configuration is unchanged keep their loop and state untouched; edited targets get a
+// new loop that carries over their history, baselines and incident state (see
+// adoptTargetState); added targets start fresh and removed targets are stopped.
+// renamed maps a target's new URL to its old one for edits that kept the same endpoint.
+// Global settings and alert strategies are not reloaded. If the engine has not been
+// started, the new targets start with the next Start.
+func (e *TargetEngine) Reload(ctx context.Context, config *TargetConfig, renamed map[string]string) error {
+	e.reloadMutex.Lock()
+	defer e.reloadMutex.Unlock()
+
+	current := e.targetStates()
+	previousByURL := make(map[string]*TargetState, len(current))
+	for _, state := range current {
+		previousByURL[state.Target.URL] = state
+	}
+
+	var errs []error
+	targets := make([]*TargetState, 0, len(config.Targets))
+	for _, target := range config.Targets {
+		sourceURL := target.URL
+		if oldURL, ok := renamed[sourceURL]; ok {
+			sourceURL = oldURL
+		}
+		prev, ok := previousByURL[sourceURL]
+		if ok {
+			delete(previousByURL, sourceURL)
+			if reflect.DeepEqual(*prev.Target, target) {
+				targets = append(targets, prev)
+				continue
+			}
+			if err := e.stopTargetLoop(ctx, prev); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		state := e.newTargetState(target)
+		if ok {
+			e.adoptTargetState(state, prev)
+			e.incidents.Rename(prev.Target.Name, state.Target)
+		}
+		targets = append(targets, state)
+		if e.runCtx != nil {
+			e.startTargetLoop(state)
+		}
+	}
+
+	// Whatever is left was removed from the configuration
+	for _, prev := range previousByURL {
+		if err := e.stopTargetLoop(ctx, prev); err != nil {
+			errs = append(errs, err)
+		}
+		if prev.CurrentAckToken != "" {
+			e.ackMutex.Lock()
+			if e.ackTokenMap[prev.CurrentAckToken] == prev {
+				delete(e.ackTokenMap, prev.CurrentAckToken)
+			}
+			e.ackMutex.Unlock()
+		}
+	}
+
+	e.targetsMutex.Lock()
+	e.config = config
+	e.targets = targets
+	e.targetsMutex.Unlock()
+	e.resolveRemovedIncidents()
+	return errors.Join(errs...)
+}
+
+// resolveRemovedIncidents closes open incidents of targets that are no longer configured
+func (e *TargetEngine) resolveRemovedIncidents() {
+	names := make(map[string]bool)
+	for _, state := range e.targetStates() {
+		names[state.Target.Name] = true
+	}
+	e.incidents.ResolveRemoved(names, time.Now())
+}
+
+// targetStates returns the current target states. Reload replaces the slice rather than
+// changing it, so callers may range over the result without holding the lock.
+func (e *TargetEngine) targetStates() []*TargetState {
+	e.targetsMutex.RLock()
+	defer e.targetsMutex.RUnlock()
+	return e.targets
+}
+
 // targetLoop runs the targeting loop for a single target
 func (e *TargetEngine) targetLoop(ctx context.Context, state *TargetState) {
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(e.checkInterval(state.Target))
 	defer ticker.Stop()
 
 	for {
@@ -389,24 +906,326 @@ func (e *TargetEngine) targetLoop(ctx context.Context, state *TargetState) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			e.checkTarget(ctx, state)
+			e.runScheduledCheck(ctx, state)
+		case <-state.checkNow:
+			e.runScheduledCheck(ctx, state)
+		case reply := <-state.checkRequests:
+			e.runScheduledCheck(ctx, state)
+			reply <- state.LastCheck
+		}
+	}
+}
+
+// runScheduledCheck checks an unpaused target once a max_concurrent_checks slot is free
+func (e *TargetEngine) runScheduledCheck(ctx context.Context, state *TargetState) {
+	if state.Target.Paused {
+		return
+	}
+	if e.checkLimiter != nil {
+		if err := e.checkLimiter.Acquire(ctx); err != nil {
+			return
 		}
+		defer e.checkLimiter.Release()
+	}
+	started := time.Now()
+	e.checkTarget(ctx, state)
+	e.checkDurations.Observe(time.Since(started))
+}
+
+// SetMaxConcurrentChecks changes how many checks may run at once (0 = unlimited); queued
+// checks start as soon as the new limit allows
+func (e *TargetEngine) SetMaxConcurrentChecks(limit int) {
+	e.checkLimiter.SetLimit(limit)
+}
+
+// TriggerCheck asks the loop for the target with the given URL to check it now rather
+// than at the next tick. Checks stay serialized in the target's loop; a trigger while
+// one is already pending is dropped. Returns false if no such target exists.
+func (e *TargetEngine) TriggerCheck(url string) bool {
+	for _, state := range e.targetStates() {
+		if state.Target.URL == url {
+			select {
+			case state.checkNow <- struct{}{}:
+			default:
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// CheckNow checks the unpaused target with the given URL right away and returns the
+// result. The check runs in the target's loop like a scheduled one, so it is recorded in
+// history and alerts as usual. Returns false if no such target exists; the result is nil
+// if ctx ends before the check finishes.
+func (e *TargetEngine) CheckNow(ctx context.Context, url string) (*CheckResult, bool) {
+	for _, state := range e.targetStates() {
+		if state.Target.URL != url {
+			continue
+		}
+		if state.loopDone == nil {
+			// Not started: nothing else is checking this target
+			e.runScheduledCheck(ctx, state)
+			return state.LastCheck, true
+		}
+		reply := make(chan *CheckResult, 1)
+		select {
+		case state.checkRequests <- reply:
+		case <-state.loopDone:
+			return nil, true
+		case <-ctx.Done():
+			return nil, true
+		}
+		select {
+		case result := <-reply:
+			return result, true
+		case <-ctx.Done():
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// TargetDiagnosis explains why a target is failing: its last failed check, what kind of
+// failure it was, and how long the outage has lasted
+type TargetDiagnosis struct {
+	URL                 string       `json:"url"`
+	Name                string       `json:"name"`
+	IsDown              bool         `json:"is_down"`
+	DownSince           *time.Time   `json:"down_since,omitempty"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	FailureType         string       `json:"failure_type,omitempty"`     // status, body, latency, timeout, dns, connection, tls, redirect, visual, dependency, triggered or error
+	FailedAssertion     string       `json:"failed_assertion,omitempty"` // status, body, latency or cert; empty when no response was judged
+	Reason              string       `json:"reason,omitempty"`
+	LastFailure         *CheckResult `json:"last_failure,omitempty"`
+}
+
+// Diagnose explains the most recent failure of the target with the given URL. The
+// diagnosis has no failure fields if the target has never failed. Returns nil if no
+// such target exists.
+func (e *TargetEngine) Diagnose(url string) *TargetDiagnosis {
+	for _, state := range e.targetStates() {
+		if state.Target.URL == url {
+			return diagnoseTarget(state)
+		}
+	}
+	return nil
+}
+
+// diagnoseTarget builds a TargetDiagnosis from a target's runtime state
+func diagnoseTarget(state *TargetState) *TargetDiagnosis {
+	diagnosis := &TargetDiagnosis{
+		URL:         state.Target.URL,
+		Name:        state.Target.Name,
+		IsDown:      state.IsDown,
+		DownSince:   state.DownSince,
+		LastFailure: state.LastFailure,
+	}
+	history := state.GetCheckHistory()
+	for i := len(history) - 1; i >= 0 && !history[i].Success; i-- {
+		diagnosis.ConsecutiveFailures++
+	}
+	if state.LastFailure != nil {
+		diagnosis.FailureType, diagnosis.FailedAssertion = classifyFailure(state.Target, state.LastFailure)
+		diagnosis.Reason = state.LastFailure.Error
+		if diagnosis.FailedAssertion == "status" {
+			expected := state.Target.StatusCodes
+			if len(expected) == 0 {
+				expected = []string{"*"}
+			}
+			diagnosis.Reason = fmt.Sprintf("HTTP %d is not an expected status code (%s)", state.LastFailure.StatusCode, strings.Join(expected, ", "))
+		}
+	}
+	return diagnosis
+}
+
+// classifyFailure maps a failed check to a failure type and, when the check got far
+// enough to judge the response, the assertion that failed
+func classifyFailure(target *Target, result *CheckResult) (failureType, assertion string) {
+	errText := strings.ToLower(result.Error)
+	switch {
+	case len(result.RedirectChain) > 0 || strings.Contains(errText, "too many redirects"):
+		return "redirect", ""
+	case strings.HasPrefix(errText, "slow "):
+		return "latency", "latency"
+	case strings.HasPrefix(errText, "body_match "):
+		return "body", "body"
+	case strings.HasPrefix(errText, "dependency "):
+		return "dependency", ""
+	case strings.Contains(errText, "x509") || strings.Contains(errText, "certificate") || strings.Contains(errText, "tls"):
+		return "tls", "cert"
+	case strings.Contains(errText, "no such host") || strings.Contains(errText, "lookup "):
+		return "dns", ""
+	case strings.Contains(errText, "timeout") || strings.Contains(errText, "deadline exceeded"):
+		return "timeout", "latency"
+	case strings.Contains(errText, "connection refused") || strings.Contains(errText, "connection reset") ||
+		strings.Contains(errText, "no route to host") || strings.HasPrefix(errText, "failed ports"):
+		return "connection", ""
+	case target.CheckStrategy == "page-comparison":
+		return "visual", "body"
+	case target.CheckStrategy == "webhook":
+		return "triggered", ""
+	case result.StatusCode > 0 && !isStatusCodeAllowed(result.StatusCode, target.StatusCodes):
+		return "status", "status"
+	}
+	return "error", ""
+}
+
+// adoptTargetState carries history, baselines and incident state over from prev, the
+// stopped state of the same target before an edit. Must be called before state's loop starts.
+func (e *TargetEngine) adoptTargetState(state, prev *TargetState) {
+	prev.historyMutex.RLock()
+	state.CheckHistory = append([]CheckHistoryEntry(nil), prev.CheckHistory...)
+	prev.historyMutex.RUnlock()
+	state.SizeHistory = prev.SizeHistory
+	state.ContentHashHistory = prev.ContentHashHistory
+	state.LastCheck = prev.LastCheck
+	state.LastFailure = prev.LastFailure
+	state.FirstCheckAt = prev.FirstCheckAt
+	state.HasSucceeded = prev.HasSucceeded
+	state.SlowSince = prev.SlowSince
+	state.SlowAlertSent = prev.SlowAlertSent
+	state.RecoverySuccesses = prev.RecoverySuccesses
+	state.FailedChecks = prev.FailedChecks
+	state.RecoveredAt = prev.RecoveredAt
+
+	// Webhook outages are driven by recovery timers, which were stopped with the old loop
+	if state.Target.CheckStrategy == "webhook" {
+		return
+	}
+	state.IsDown = prev.IsDown
+	state.DownSince = prev.DownSince
+	state.FailureCount = prev.FailureCount
+	state.LastAlertTime = prev.LastAlertTime
+	state.FirstAlertTime = prev.FirstAlertTime
+	state.EscalationStage = prev.EscalationStage
+	state.AcknowledgedBy = prev.AcknowledgedBy
+	state.AcknowledgedAt = prev.AcknowledgedAt
+	state.AcknowledgementNote = prev.AcknowledgementNote
+	state.AcknowledgementContact = prev.AcknowledgementContact
+	prev.dependentsMutex.Lock()
+	state.SuppressedDependents = prev.SuppressedDependents
+	state.PendingDependents = prev.PendingDependents
+	prev.dependentsMutex.Unlock()
+	if prev.CurrentAckToken != "" {
+		state.CurrentAckToken = prev.CurrentAckToken
+		e.ackMutex.Lock()
+		e.ackTokenMap[state.CurrentAckToken] = state
+		e.ackMutex.Unlock()
+	}
+}
+
+// urlChangedMaterially reports whether newURL points at a different endpoint than oldURL,
+// ignoring case in the scheme and host, default ports and a trailing slash
+func urlChangedMaterially(oldURL, newURL string) bool {
+	if oldURL == newURL {
+		return false
+	}
+	oldParsed, oldErr := url.Parse(oldURL)
+	newParsed, newErr := url.Parse(newURL)
+	if oldErr != nil || newErr != nil {
+		return true
+	}
+	normalize := func(u *url.URL) string {
+		scheme := strings.ToLower(u.Scheme)
+		host := strings.ToLower(u.Hostname())
+		port := u.Port()
+		if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+			port = ""
+		}
+		return scheme + "://" + host + ":" + port + strings.TrimRight(u.Path, "/") + "?" + u.RawQuery
+	}
+	return normalize(oldParsed) != normalize(newParsed)
+}
+
+// Limits for Target.Retries
+const (
+	maxCheckRetries   = 5
+	checkRetryBackoff = 250 * time.Millisecond // doubled after each retry
+)
+
+// applyRetryOnFailureAlias maps the deprecated retry_on_failure onto retries, so targets
+// configured before the two were merged keep retrying
+func applyRetryOnFailureAlias(target *Target) {
+	if target.Retries == 0 {
+		target.Retries = target.RetryOnFailure
+	}
+	target.RetryOnFailure = 0
+}
+
+// runCheckWithRetries runs the target's check strategy, re-checking a failure up to
+// Target.Retries times with backoff. Only the final attempt's result is returned, so a
+// successful retry records a single successful check. Targets with urls check every
+// backend this way and combine the results (see checkBackends).
+func (e *TargetEngine) runCheckWithRetries(ctx context.Context, state *TargetState) *CheckResult {
+	if len(state.Target.URLs) > 0 {
+		return checkBackends(state.Target, e.checkLimiter, func(backend *Target) *CheckResult {
+			return e.checkWithRetries(ctx, state.CheckStrategy, backend)
+		})
+	}
+	return e.checkWithRetries(ctx, state.CheckStrategy, state.Target)
+}
+
+// checkWithRetries runs one check of target with strategy, retrying failures as described
+// on runCheckWithRetries
+func (e *TargetEngine) checkWithRetries(ctx context.Context, strategy CheckStrategy, target *Target) *CheckResult {
+	retries := min(max(target.Retries, 0), maxCheckRetries)
+	backoff := checkRetryBackoff
+	for attempt := 0; ; attempt++ {
+		result, err := strategy.Check(ctx, target)
+		if err != nil {
+			// Handle check error
+			result = &CheckResult{
+				Success:   false,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			}
+		}
+		if attempt > 0 && e.settings.Debug {
+			outcome := "succeeded"
+			if !result.Success {
+				outcome = "failed: " + result.Error
+			}
+			logEvent("check.retry", target.Name, "Retry %d/%d for %s %s", attempt, retries, target.Name, outcome)
+		}
+		if result.Success || attempt >= retries {
+			return result
+		}
+		if e.settings.Debug {
+			logEvent("check.retry", target.Name, "Check of %s failed (%s); retrying in %s", target.Name, result.Error, backoff)
+		}
+		select {
+		case <-ctx.Done():
+			return result
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
 }
 
 // checkTarget performs a single check for a target
 func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
-	result, err := state.CheckStrategy.Check(ctx, state.Target)
-	if err != nil {
-		// Handle check error
-		result = &CheckResult{
-			Success:   false,
-			Error:     err.Error(),
-			Timestamp: time.Now(),
-		}
+	// Dependents suppressed since this target's last check share one note per alert
+	if state.IsDown {
+		e.sendDependencyNotes(ctx, state)
 	}
+	result := e.runCheckWithRetries(ctx, state)
 
 	state.LastCheck = result
+	if !result.Success {
+		result.DetailURL = e.targetDetailURL(state)
+		state.LastFailure = result
+	}
+	if e.otlp != nil {
+		e.otlp.RecordCheck(state.Target, result)
+	}
+	if state.FirstCheckAt == nil {
+		firstCheck := result.Timestamp
+		state.FirstCheckAt = &firstCheck
+	}
+	if result.Success {
+		state.HasSucceeded = true
+	}
 
 	// Create history entry (will be updated with alert info later)
 	historyEntry := CheckHistoryEntry{
@@ -425,6 +1244,8 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 		VisualDifference: result.VisualDifference,
 		ScreenshotPath:   result.ScreenshotPath,
 		DiffImagePath:    result.DiffImagePath,
+		Timings:          result.Timings,
+		Backends:         result.Backends,
 	}
 
 	// Check for size changes if enabled and we have a response size
@@ -439,45 +1260,95 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 			avgSize := float64(sum) / float64(len(previousResponses))
 			changePercent := math.Abs(float64(result.ResponseSize)-avgSize) / avgSize
 
-			// Send size change alert to console strategies
-			for _, strat := range state.AlertStrategies {
-				if consoleAlert, ok := strat.(*ConsoleAlertStrategy); ok {
-					consoleAlert.SendSizeChangeAlert(ctx, state.Target, result, avgSize, changePercent)
+			// Send size change alert to the strategies that can report it. Without a size
+			// route only console alerts report it, as they did before routing existed.
+			for _, strat := range e.routedAlertStrategies(state, "size") {
+				if _, console := strat.(*ConsoleAlertStrategy); !console && len(state.Target.AlertRoutes["size"]) == 0 {
+					continue
+				}
+				if _, ok := strat.(SizeChangeAwareAlert); ok {
+					e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+						if sizeSender, ok := s.(SizeChangeAwareAlert); ok {
+							return sizeSender.SendSizeChangeAlert(ctx, state.Target, result, avgSize, changePercent)
+						}
+						return nil
+					})
+				}
+			}
+		}
+	}
+
+	// Report content changes from one successful check to the next
+	if result.Success {
+		if previousHash, changed := checkContentChange(state, result.ContentHash); changed {
+			for _, strat := range e.routedAlertStrategies(state, "content") {
+				if _, ok := strat.(ContentChangeAwareAlert); ok {
+					e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+						if contentSender, ok := s.(ContentChangeAwareAlert); ok {
+							return contentSender.SendContentChangeAlert(ctx, state.Target, result, previousHash)
+						}
+						return nil
+					})
 				}
 			}
 		}
 	}
 
+	e.trackSlowResponse(ctx, state, result, &historyEntry)
+
 	// Update state based on result
 	wasDown := state.IsDown
+	if result.Success && wasDown && e.awaitingRecovery(state) {
+		// Stay down until recovery_threshold consecutive checks pass
+		state.AddCheckHistory(historyEntry)
+		e.publishCheckEvents(state, historyEntry, wasDown)
+		return
+	}
+	state.RecoverySuccesses = 0
 	state.IsDown = !result.Success
-
-	// Get threshold (default 30 seconds if not set)
-	threshold := state.Target.Threshold
-	if threshold == 0 {
-		threshold = 30
+	if result.Success {
+		state.FailedChecks = 0
+	} else {
+		state.FailedChecks++
 	}
-	thresholdDuration := time.Duration(threshold) * time.Second
+	flapping := e.trackFlapping(ctx, state, result, wasDown)
 
 	if !result.Success && !wasDown {
 		// Just started failing - record the time but DON'T alert yet
+		logEvent("check.failed", state.Target.Name, "Check of %s failed: %s", state.Target.Name, result.Error)
 		now := time.Now()
 		state.DownSince = &now
 		// Don't set FailureCount, LastAlertTime, or send alerts yet
-		// Wait until threshold is exceeded
-	} else if !result.Success && wasDown {
+		// Wait until threshold is exceeded (unless failure_count: 1 is already reached)
+	}
+	if !result.Success && (wasDown || downThresholdReached(state)) {
 		// Still failing - check if we've exceeded the threshold
 		if state.DownSince != nil {
-			downDuration := time.Since(*state.DownSince)
-
-			// Check if we've been down long enough to send an alert
-			if downDuration >= thresholdDuration {
-				// If this is the first alert, initialize the alert state
-				if state.FailureCount == 0 {
+			// Check if we've been down long enough to send an alert (brand-new targets that
+			// have never succeeded, and targets that just recovered, get an extra grace period)
+			if downThresholdReached(state) && !e.inInitialGrace(state) && !e.inRecoveryCooldown(state) {
+				// Open an incident even when the alert below is suppressed
+				e.incidents.Open(state.Target, *state.DownSince, result.Error)
+				if dep := e.downDependency(state); dep != nil {
+					// A dependency is down: record the failure but don't page. FailureCount stays
+					// untouched so the target alerts normally if it is still down after the dependency recovers.
+					historyEntry.SuppressedBy = dep.Target.Name
+					e.noteSuppressedDependent(dep, state)
+				} else if e.pagingSuppressed(state.Target) {
+					// Critical-only mode: record and log, but don't page non-critical targets
+					historyEntry.SuppressedBy = "critical-only paging"
+					logEvent("alert.suppressed", state.Target.Name, "Critical-only paging: not alerting for %s (severity %q): %s", state.Target.Name, state.Target.Severity, result.Error)
+				} else if flapping {
+					// One "flapping" alert already covers this; alerting resumes once the target is stable
+					historyEntry.SuppressedBy = "flapping"
+				} else if state.FailureCount == 0 {
+					// If this is the first alert, initialize the alert state
 					// First alert after threshold exceeded
 					now := time.Now()
 					state.FailureCount = 1
 					state.LastAlertTime = &now
+					state.FirstAlertTime = &now
+					state.EscalationStage = 0
 
 					// Set alert count in result for display
 					result.AlertCount = state.FailureCount
@@ -489,12 +1360,8 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 						ackURL = e.GetAcknowledgementURL(token)
 					}
 
-					for _, strat := range state.AlertStrategies {
-						if ackSender, ok := strat.(AcknowledgementAwareAlert); ok && ackURL != "" {
-							ackSender.SendAlertWithAck(ctx, state.Target, result, ackURL)
-						} else {
-							strat.SendAlert(ctx, state.Target, result)
-						}
+					for _, strat := range e.routedAlertStrategies(state, "down") {
+						e.deliver(ctx, state.Target, strat, downAlertSender(ctx, state.Target, result, ackURL))
 					}
 
 					// Update history entry
@@ -504,18 +1371,20 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 					// Track metric: alert sent
 					e.metrics.mutex.Lock()
 					e.metrics.AlertsSent++
+					e.metrics.AlertsSentTotal++
 					e.metrics.mutex.Unlock()
 				} else {
+					// An acknowledgement older than settings.ack_ttl lapses and alerting resumes at once
+					expiredAckNote := e.expireAcknowledgement(state)
+
 					// Already sent at least one alert, check if we should send another (exponential backoff)
 					if state.AcknowledgedAt == nil {
-						// Calculate exponential backoff based on how many alerts we've already sent
-						// Formula: 5 * 2^(FailureCount-1) seconds
-						// FailureCount=1 -> 5s, FailureCount=2 -> 10s, FailureCount=3 -> 20s, etc.
-						backoffSeconds := 5 * (1 << uint(state.FailureCount-1))
-						backoffDuration := time.Duration(backoffSeconds) * time.Second
+						// Back off based on how many alerts we've already sent (settings.alert_backoff);
+						// with the defaults FailureCount=1 -> 1m, FailureCount=2 -> 5m, then every 15m
+						backoffDuration := e.settings.AlertBackoff.Delay(state.FailureCount)
 
 						// Check if enough time has passed since last alert
-						if state.LastAlertTime != nil && time.Since(*state.LastAlertTime) >= backoffDuration {
+						if expiredAckNote != "" || (state.LastAlertTime != nil && time.Since(*state.LastAlertTime) >= backoffDuration) {
 							// Time to send another alert
 							now := time.Now()
 							state.LastAlertTime = &now
@@ -535,12 +1404,16 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 								}
 							}
 
-							for _, strat := range state.AlertStrategies {
-								if ackSender, ok := strat.(AcknowledgementAwareAlert); ok && ackURL != "" {
-									ackSender.SendAlertWithAck(ctx, state.Target, result, ackURL)
-								} else {
-									strat.SendAlert(ctx, state.Target, result)
-								}
+							// Tell responders this re-alert is an escalation of a lapsed acknowledgement
+							alertResult := result
+							if expiredAckNote != "" {
+								escalated := *result
+								escalated.Error = expiredAckNote + ": " + result.Error
+								alertResult = &escalated
+							}
+
+							for _, strat := range e.routedAlertStrategies(state, "down") {
+								e.deliver(ctx, state.Target, strat, downAlertSender(ctx, state.Target, alertResult, ackURL))
 							}
 
 							// Update history entry
@@ -550,10 +1423,17 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 							// Track metric: alert sent
 							e.metrics.mutex.Lock()
 							e.metrics.AlertsSent++
+							e.metrics.AlertsSentTotal++
 							e.metrics.mutex.Unlock()
 						}
+
+						// Page the escalation stages whose delay has now passed
+						if e.escalate(ctx, state, result) {
+							historyEntry.AlertSent = true
+							historyEntry.AlertCount = state.FailureCount
+						}
 					}
-					// If acknowledged, don't send any more alerts until service recovers
+					// If acknowledged, don't send any more alerts (or escalate) until service recovers
 				}
 			}
 			// Else: haven't been down long enough yet, don't alert
@@ -562,6 +1442,7 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 		// Just came back up - but only send ALL CLEAR if we actually sent an alert
 		// (i.e., the target was down long enough to exceed the threshold)
 		shouldSendAllClear := state.FailureCount > 0
+		wasAcked := state.AcknowledgedAt != nil
 
 		// Clear acknowledgement and reset counters
 		e.ClearAcknowledgement(state)
@@ -572,29 +1453,44 @@ func (e *TargetEngine) checkTarget(ctx context.Context, state *TargetState) {
 			e.metrics.mutex.Lock()
 			e.metrics.ResolvedOutages = append(e.metrics.ResolvedOutages, ResolvedOutage{
 				TargetName:   state.Target.Name,
+				TargetTags:   state.Target.Tags,
 				ResolvedAt:   time.Now(),
 				DownDuration: downDuration,
+				Acknowledged: wasAcked,
 			})
 			e.metrics.mutex.Unlock()
 		}
 
+		if shouldSendAllClear {
+			recoveredAt := result.Timestamp
+			state.RecoveredAt = &recoveredAt
+		}
 		state.DownSince = nil
 		state.FailureCount = 0
 		state.LastAlertTime = nil
+		clearSuppressedDependents(state)
 
 		// Update history entry to mark recovery
 		historyEntry.WasRecovered = true
 
 		// Only send ALL CLEAR if we actually sent an alert before
-		if shouldSendAllClear {
-			for _, strat := range state.AlertStrategies {
-				strat.SendAllClear(ctx, state.Target, result)
-			}
+		if shouldSendAllClear && flapping {
+			historyEntry.SuppressedBy = "flapping"
+		} else if shouldSendAllClear {
+			e.sendRecovery(ctx, state, result, wasAcked)
 		}
+		state.FirstAlertTime = nil
+		state.EscalationStage = 0
+	}
+
+	// Close any open incident, including one left open by a restart during an outage
+	if !state.IsDown {
+		e.incidents.Resolve(state.Target.Name, result.Timestamp)
 	}
 
 	// Save history entry
 	state.AddCheckHistory(historyEntry)
+	e.publishCheckEvents(state, historyEntry, wasDown)
 }
 
 // HandleWebhookNotification handles incoming webhook notifications
@@ -610,7 +1506,7 @@ func (e *TargetEngine) HandleWebhookNotification(ctx context.Context, notificati
 
 // GetTargetStatus returns the current status of all targets
 func (e *TargetEngine) GetTargetStatus() []*TargetState {
-	return e.targets
+	return e.targetStates()
 }
 
 // SetAcknowledgementConfig configures acknowledgement settings
@@ -627,6 +1523,26 @@ func (e *TargetEngine) GetAcknowledgementURL(token string) string {
 	return fmt.Sprintf("%s/api/acknowledge/%s", e.serverAddress, token)
 }
 
+// SetCriticalOnly toggles critical-only paging mode
+func (e *TargetEngine) SetCriticalOnly(enabled bool) {
+	e.criticalOnly.Store(enabled)
+}
+
+// CriticalOnly reports whether critical-only paging mode is active
+func (e *TargetEngine) CriticalOnly() bool {
+	return e.criticalOnly.Load()
+}
+
+// pagingSuppressed reports whether DOWN alerts for the target are held back by critical-only mode
+func (e *TargetEngine) pagingSuppressed(target *Target) bool {
+	return e.criticalOnly.Load() && target.Severity != "critical"
+}
+
+// targetDetailURL returns the link to the target's detail page
+func (e *TargetEngine) targetDetailURL(state *TargetState) string {
+	return fmt.Sprintf("%s/targets/%s", e.serverAddress, state.GetURLSafeName())
+}
+
 // GenerateAckToken generates and stores an acknowledgement token for a target
 func (e *TargetEngine) GenerateAckToken(state *TargetState) string {
 	e.ackMutex.Lock()
@@ -670,6 +1586,8 @@ func (e *TargetEngine) AcknowledgeAlert(token, acknowledgedBy, note, contact str
 		state.AcknowledgementContact = contact
 	}
 
+	e.incidents.Acknowledge(state.Target.Name, state.AcknowledgedBy, *state.AcknowledgedAt)
+
 	// For page-comparison targets: Delete baseline images on first acknowledgement
 	// This resets the target and forces new baselines to be created
 	if isFirstAcknowledgement && state.Target.CheckStrategy == "page-comparison" {
@@ -682,6 +1600,25 @@ func (e *TargetEngine) AcknowledgeAlert(token, acknowledgedBy, note, contact str
 	return state, nil
 }
 
+// expireAcknowledgement clears an acknowledgement older than settings.ack_ttl on a target
+// that is still down, returning the escalation note for the re-alert ("" if nothing expired)
+func (e *TargetEngine) expireAcknowledgement(state *TargetState) string {
+	ttl := time.Duration(e.settings.AckTTL) * time.Second
+	e.ackMutex.RLock()
+	ackedAt, ackedBy := state.AcknowledgedAt, state.AcknowledgedBy
+	e.ackMutex.RUnlock()
+	if ttl <= 0 || ackedAt == nil || time.Since(*ackedAt) < ttl {
+		return ""
+	}
+
+	if ackedBy == "" {
+		ackedBy = "unknown"
+	}
+	e.ClearAcknowledgement(state)
+	log.Printf("Acknowledgement of %s by %s expired after %s while still down; resuming alerts", state.Target.Name, ackedBy, ttl)
+	return fmt.Sprintf("ESCALATION: acknowledgement by %s expired after %s and the target is still down", ackedBy, ttl)
+}
+
 // ClearAcknowledgement clears acknowledgement when alert is resolved
 func (e *TargetEngine) ClearAcknowledgement(state *TargetState) {
 	e.ackMutex.Lock()
@@ -704,12 +1641,12 @@ func (e *TargetEngine) ClearAcknowledgement(state *TargetState) {
 // This forces the target to re-initialize with new baseline images
 func (e *TargetEngine) deleteBaselineImages(targetName string) {
 	screenshotPath := "screenshots"
-	
+
 	// Sanitize target name for file path (same logic as in strategies.go)
 	safeName := strings.ReplaceAll(targetName, " ", "_")
 	safeName = strings.ReplaceAll(safeName, "/", "-")
 	safeName = strings.ToLower(safeName)
-	
+
 	// Delete all 5 baseline images
 	deletedCount := 0
 	for i := 1; i <= 5; i++ {
@@ -718,7 +1655,7 @@ func (e *TargetEngine) deleteBaselineImages(targetName string) {
 			deletedCount++
 		}
 	}
-	
+
 	if deletedCount > 0 {
 		log.Printf("Acknowledged alert for %s: Deleted %d baseline image(s), will re-initialize", targetName, deletedCount)
 	}
@@ -728,7 +1665,7 @@ func (e *TargetEngine) deleteBaselineImages(targetName string) {
 func (e *TargetEngine) TriggerWebhookTarget(targetName string, message string, duration int) (*TargetState, error) {
 	// Find the target by name
 	var state *TargetState
-	for _, s := range e.targets {
+	for _, s := range e.targetStates() {
 		if s.Target.Name == targetName || s.Target.URL == targetName {
 			state = s
 			break
@@ -743,6 +1680,9 @@ func (e *TargetEngine) TriggerWebhookTarget(targetName string, message string, d
 	if state.Target.CheckStrategy != "webhook" {
 		return nil, fmt.Errorf("target %s is not a webhook target (check_strategy must be 'webhook')", targetName)
 	}
+	if state.Target.Paused {
+		return nil, fmt.Errorf("target %s is paused", targetName)
+	}
 
 	// Cancel any existing recovery timer
 	if state.RecoveryTimer != nil {
@@ -765,6 +1705,8 @@ func (e *TargetEngine) TriggerWebhookTarget(targetName string, message string, d
 		ResponseTime: 0,
 		Timestamp:    now,
 	}
+	state.LastFailure = state.LastCheck
+	e.incidents.Open(state.Target, now, message)
 
 	// Use duration from trigger, or fall back to target's duration
 	actualDuration := duration
@@ -781,6 +1723,7 @@ func (e *TargetEngine) TriggerWebhookTarget(targetName string, message string, d
 			e.RecoverWebhookTarget(state)
 		})
 	}
+	e.sendWebhookCallback(state, "triggered", message)
 
 	// Generate acknowledgement token if enabled and not already acknowledged
 	ctx := context.Background()
@@ -791,12 +1734,8 @@ func (e *TargetEngine) TriggerWebhookTarget(targetName string, message string, d
 	}
 
 	// Send alerts
-	for _, strat := range state.AlertStrategies {
-		if ackSender, ok := strat.(AcknowledgementAwareAlert); ok && ackURL != "" {
-			ackSender.SendAlertWithAck(ctx, state.Target, state.LastCheck, ackURL)
-		} else {
-			strat.SendAlert(ctx, state.Target, state.LastCheck)
-		}
+	for _, strat := range e.routedAlertStrategies(state, "down") {
+		e.deliver(ctx, state.Target, strat, downAlertSender(ctx, state.Target, state.LastCheck, ackURL))
 	}
 
 	return state, nil
@@ -809,6 +1748,7 @@ func (e *TargetEngine) RecoverWebhookTarget(state *TargetState) {
 	}
 
 	// Clear acknowledgement
+	wasAcked := state.AcknowledgedAt != nil
 	e.ClearAcknowledgement(state)
 
 	// Mark as up
@@ -818,6 +1758,7 @@ func (e *TargetEngine) RecoverWebhookTarget(state *TargetState) {
 	state.RecoveryTime = nil
 	state.FailureCount = 0
 	state.LastAlertTime = nil
+	clearSuppressedDependents(state)
 
 	// Create recovery check result
 	state.LastCheck = &CheckResult{
@@ -827,16 +1768,507 @@ func (e *TargetEngine) RecoverWebhookTarget(state *TargetState) {
 		Timestamp:    time.Now(),
 	}
 
+	e.incidents.Resolve(state.Target.Name, state.LastCheck.Timestamp)
+
 	// Send all-clear notifications
-	ctx := context.Background()
-	for _, strat := range state.AlertStrategies {
-		strat.SendAllClear(ctx, state.Target, state.LastCheck)
+	e.sendRecovery(context.Background(), state, state.LastCheck, wasAcked)
+	e.sendWebhookCallback(state, "recovered", "")
+}
+
+// defaultCheckInterval is used when neither the target nor settings set an interval
+const defaultCheckInterval = 5 * time.Second
+
+// checkInterval returns how often a target is checked: its own interval, else
+// settings.check_interval, else defaultCheckInterval
+func (e *TargetEngine) checkInterval(target *Target) time.Duration {
+	if target.Interval > 0 {
+		return time.Duration(target.Interval) * time.Second
+	}
+	if e.settings.CheckInterval > 0 {
+		return time.Duration(e.settings.CheckInterval) * time.Second
+	}
+	return defaultCheckInterval
+}
+
+// inInitialGrace reports whether a target that has never passed a check is still within
+// its initial grace period, during which DOWN alerts are held back
+func (e *TargetEngine) inInitialGrace(state *TargetState) bool {
+	if state.HasSucceeded || state.FirstCheckAt == nil {
+		return false
+	}
+	grace := state.Target.InitialGraceSeconds
+	if grace == 0 {
+		grace = e.settings.InitialGraceSeconds
+	}
+	if grace <= 0 {
+		return false
+	}
+	return time.Since(*state.FirstCheckAt) < time.Duration(grace)*time.Second
+}
+
+// inRecoveryCooldown reports whether a target is within recovery_cooldown_seconds of the
+// ALL CLEAR that ended its last incident, during which new DOWN alerts are held back. A
+// failure that outlasts the cooldown alerts as usual.
+func (e *TargetEngine) inRecoveryCooldown(state *TargetState) bool {
+	if state.RecoveredAt == nil {
+		return false
+	}
+	cooldown := state.Target.RecoveryCooldownSeconds
+	if cooldown == 0 {
+		cooldown = e.settings.RecoveryCooldownSeconds
+	}
+	if cooldown <= 0 {
+		return false
+	}
+	return time.Since(*state.RecoveredAt) < time.Duration(cooldown)*time.Second
+}
+
+// downThresholdReached reports whether a failing target has failed long enough to alert:
+// failure_count consecutive failed checks when set, otherwise threshold seconds (default 30)
+// since its first failure
+func downThresholdReached(state *TargetState) bool {
+	if state.Target.DownAfterFailures > 0 {
+		return state.FailedChecks >= state.Target.DownAfterFailures
+	}
+	threshold := cmp.Or(state.Target.Threshold, 30)
+	return state.DownSince != nil && time.Since(*state.DownSince) >= time.Duration(threshold)*time.Second
+}
+
+// describeThreshold renders the target's down threshold, e.g. "30s" or "3 failed checks"
+func describeThreshold(target Target) string {
+	if target.DownAfterFailures > 0 {
+		return fmt.Sprintf("%d failed checks", target.DownAfterFailures)
+	}
+	return fmt.Sprintf("%ds", cmp.Or(target.Threshold, 30))
+}
+
+// recoveryThreshold returns how many consecutive successful checks a down target needs to recover
+func (e *TargetEngine) recoveryThreshold(target *Target) int {
+	if target.RecoveryThreshold > 0 {
+		return target.RecoveryThreshold
+	}
+	return max(e.settings.RecoveryThreshold, 1)
+}
+
+// awaitingRecovery counts a successful check of a down target and reports whether it
+// still needs more successes in a row before it is declared recovered
+func (e *TargetEngine) awaitingRecovery(state *TargetState) bool {
+	state.RecoverySuccesses++
+	return state.RecoverySuccesses < e.recoveryThreshold(state.Target)
+}
+
+// requiresAckForAutoresolve reports whether an unacknowledged recovery of the target
+// should be downgraded from an all-clear to a "resolved without acknowledgement" note
+func (e *TargetEngine) requiresAckForAutoresolve(target *Target) bool {
+	if target.RequireAckForAutoresolve != nil {
+		return *target.RequireAckForAutoresolve
+	}
+	return e.settings.RequireAckForAutoresolve
+}
+
+// sendRecovery notifies the target's alert strategies that it has recovered
+func (e *TargetEngine) sendRecovery(ctx context.Context, state *TargetState, result *CheckResult, wasAcked bool) {
+	strategies := e.recoveryAlertStrategies(state)
+	if wasAcked || !e.requiresAckForAutoresolve(state.Target) {
+		for _, strat := range strategies {
+			e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+				return s.SendAllClear(ctx, state.Target, result)
+			})
+		}
+		return
+	}
+
+	// Strategies without a dedicated note still hear about the recovery as an all-clear
+	for _, strat := range strategies {
+		e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+			if noteSender, ok := s.(UnacknowledgedResolutionAwareAlert); ok {
+				return noteSender.SendResolvedWithoutAck(ctx, state.Target, result)
+			}
+			return s.SendAllClear(ctx, state.Target, result)
+		})
+	}
+}
+
+// recoveryAlertStrategies returns the recovery route plus any alerts the incident was
+// escalated to, so every paged alert hears that it is over
+func (e *TargetEngine) recoveryAlertStrategies(state *TargetState) []AlertStrategy {
+	strategies := e.routedAlertStrategies(state, "recovery")
+	stages := e.escalationStages(state.Target)
+	var escalated []AlertStrategy
+	for _, stage := range stages[:min(state.EscalationStage, len(stages))] {
+		for _, name := range stage.Alerts {
+			if strategy, exists := e.alertStrategies[name]; exists && !slices.Contains(strategies, strategy) && !slices.Contains(escalated, strategy) {
+				escalated = append(escalated, strategy)
+			}
+		}
+	}
+	return append(strategies, e.scheduledAlertStrategies(state, "recovery", escalated)...)
+}
+
+// escalationStages returns the target's escalation stages, or settings.escalation when it has none
+func (e *TargetEngine) escalationStages(target *Target) []EscalationStage {
+	if len(target.Escalation) > 0 {
+		return target.Escalation
+	}
+	return e.settings.Escalation
+}
+
+// escalate pages each escalation stage not yet paged whose after_minutes have passed since
+// the incident's first DOWN alert, and reports whether anything was sent. Callers only
+// escalate unacknowledged incidents, so an acknowledgement stops escalation.
+func (e *TargetEngine) escalate(ctx context.Context, state *TargetState, result *CheckResult) bool {
+	stages := e.escalationStages(state.Target)
+	if state.Target.Paused || state.FirstAlertTime == nil || state.EscalationStage >= len(stages) {
+		return false
+	}
+
+	unacked := time.Since(*state.FirstAlertTime)
+	sent := false
+	for state.EscalationStage < len(stages) {
+		stage := stages[state.EscalationStage]
+		if unacked < time.Duration(stage.AfterMinutes)*time.Minute {
+			break
+		}
+		state.EscalationStage++
+
+		var ackURL string
+		if e.acksEnabled {
+			if state.CurrentAckToken == "" {
+				e.GenerateAckToken(state)
+			}
+			ackURL = e.GetAcknowledgementURL(state.CurrentAckToken)
+		}
+
+		escalated := *result
+		escalated.Error = fmt.Sprintf("Escalated (stage %d, unacknowledged for %s): %s", state.EscalationStage, unacked.Round(time.Second), result.Error)
+		logEvent("alert.escalated", state.Target.Name, "Escalating %s to stage %d (%s) after %s unacknowledged", state.Target.Name, state.EscalationStage, strings.Join(stage.Alerts, ", "), unacked.Round(time.Second))
+		var stageStrategies []AlertStrategy
+		for _, name := range stage.Alerts {
+			strat, exists := e.alertStrategies[name]
+			if !exists {
+				log.Printf("Warning: escalation stage %d of %s names unknown alert '%s'", state.EscalationStage, state.Target.Name, name)
+				continue
+			}
+			stageStrategies = append(stageStrategies, strat)
+		}
+		// Stage alerts are DOWN alerts, so they honor the notifiers' quiet and active hours
+		for _, strat := range e.scheduledAlertStrategies(state, "down", stageStrategies) {
+			e.deliver(ctx, state.Target, strat, downAlertSender(ctx, state.Target, &escalated, ackURL))
+		}
+		sent = true
+	}
+
+	if sent {
+		e.metrics.mutex.Lock()
+		e.metrics.AlertsSent++
+		e.metrics.AlertsSentTotal++
+		e.metrics.mutex.Unlock()
+	}
+	return sent
+}
+
+// trackFlapping records an up/down change and reports whether the target is flapping:
+// more than flap_detection.transitions changes within the window. The check that finds
+// it flapping sends one "flapping" alert; once a full window passes without a change the
+// target is stable again, an up target gets an all-clear and normal alerting resumes.
+func (e *TargetEngine) trackFlapping(ctx context.Context, state *TargetState, result *CheckResult, wasDown bool) bool {
+	config := e.settings.FlapDetection
+	if config.Transitions <= 0 {
+		state.StateTransitions = nil
+		state.FlappingSince = nil
+		return false
+	}
+
+	window := config.Window()
+	if wasDown != state.IsDown {
+		state.StateTransitions = append(state.StateTransitions, result.Timestamp)
+	}
+	recent := state.StateTransitions[:0]
+	for _, at := range state.StateTransitions {
+		if result.Timestamp.Sub(at) < window {
+			recent = append(recent, at)
+		}
+	}
+	state.StateTransitions = recent
+
+	if state.FlappingSince != nil {
+		if len(recent) > 0 {
+			return true
+		}
+		logEvent("target.stable", state.Target.Name, "%s is stable again: no state change for %v", state.Target.Name, window)
+		state.FlappingSince = nil
+		if !state.IsDown && !e.pagingSuppressed(state.Target) {
+			for _, strat := range e.routedAlertStrategies(state, "recovery") {
+				e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+					return s.SendAllClear(ctx, state.Target, result)
+				})
+			}
+		}
+		return false
+	}
+	if len(recent) <= config.Transitions {
+		return false
+	}
+
+	flappingSince := result.Timestamp
+	state.FlappingSince = &flappingSince
+	logEvent("target.flapping", state.Target.Name, "%s is flapping: %d state changes in %v", state.Target.Name, len(recent), window)
+	if e.pagingSuppressed(state.Target) {
+		return true
+	}
+	note := &CheckResult{
+		Success:      false,
+		StatusCode:   result.StatusCode,
+		ResponseTime: result.ResponseTime,
+		Timestamp:    result.Timestamp,
+		DetailURL:    e.targetDetailURL(state),
+		Error:        fmt.Sprintf("FLAPPING: %d up/down changes in %v; holding DOWN alerts and all-clears until there is none for %v", len(recent), window, window),
+	}
+	for _, strat := range e.routedAlertStrategies(state, "down") {
+		e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+			return s.SendAlert(ctx, state.Target, note)
+		})
+	}
+	e.metrics.mutex.Lock()
+	e.metrics.AlertsSent++
+	e.metrics.AlertsSentTotal++
+	e.metrics.mutex.Unlock()
+	return true
+}
+
+// trackSlowResponse raises a SLOW alert once successful checks have exceeded the target's
+// max_response_time for its threshold, and clears it when a check is fast again. A failed
+// check ends the slow period silently; DOWN alerting takes over from there.
+func (e *TargetEngine) trackSlowResponse(ctx context.Context, state *TargetState, result *CheckResult, historyEntry *CheckHistoryEntry) {
+	limit := time.Duration(state.Target.MaxResponseTime) * time.Millisecond
+	if limit <= 0 || !result.Success {
+		state.SlowSince = nil
+		state.SlowAlertSent = false
+		return
+	}
+
+	if result.ResponseTime <= limit {
+		if state.SlowAlertSent {
+			for _, strat := range e.slowAlertStrategies(state) {
+				e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+					if slowSender, ok := s.(SlowResponseAwareAlert); ok {
+						return slowSender.SendSlowCleared(ctx, state.Target, result)
+					}
+					return nil
+				})
+			}
+		}
+		state.SlowSince = nil
+		state.SlowAlertSent = false
+		return
+	}
+
+	historyEntry.Slow = true
+	if state.SlowSince == nil {
+		slowSince := result.Timestamp
+		state.SlowSince = &slowSince
+	}
+	threshold := state.Target.Threshold
+	if threshold == 0 {
+		threshold = 30
+	}
+	if state.SlowAlertSent || result.Timestamp.Sub(*state.SlowSince) < time.Duration(threshold)*time.Second {
+		return
 	}
+	if e.pagingSuppressed(state.Target) {
+		historyEntry.SuppressedBy = "critical-only paging"
+		logEvent("alert.suppressed", state.Target.Name, "Critical-only paging: not alerting for slow %s (severity %q): %v exceeds %v", state.Target.Name, state.Target.Severity, result.ResponseTime, limit)
+		return
+	}
+
+	state.SlowAlertSent = true
+	historyEntry.AlertSent = true
+	for _, strat := range e.slowAlertStrategies(state) {
+		e.deliver(ctx, state.Target, strat, func(s AlertStrategy) error {
+			if slowSender, ok := s.(SlowResponseAwareAlert); ok {
+				return slowSender.SendSlowAlert(ctx, state.Target, result)
+			}
+			return nil
+		})
+	}
+	e.metrics.mutex.Lock()
+	e.metrics.AlertsSent++
+	e.metrics.AlertsSentTotal++
+	e.metrics.mutex.Unlock()
+}
+
+// alertRouteEvents are the event types a target's alert_routes can send to their own alerts
+var alertRouteEvents = []string{"down", "recovery", "slow", "size", "content"}
+
+// routedAlertStrategies resolves the alerts that receive event for the target: its
+// alert_routes entry when set, otherwise its regular alerts (slow_alerts still applies
+// to "slow" when there is no route for it). Paused targets get none.
+func (e *TargetEngine) routedAlertStrategies(state *TargetState, event string) []AlertStrategy {
+	if state.Target.Paused {
+		return nil
+	}
+	names := state.Target.AlertRoutes[event]
+	if len(names) == 0 && event == "slow" {
+		names = state.Target.SlowAlerts
+	}
+	if len(names) == 0 {
+		return e.scheduledAlertStrategies(state, event, state.AlertStrategies)
+	}
+	var strategies []AlertStrategy
+	for _, name := range names {
+		if strategy, exists := e.alertStrategies[name]; exists {
+			strategies = append(strategies, strategy)
+		}
+	}
+	return e.scheduledAlertStrategies(state, event, strategies)
+}
+
+// downAlertSender sends a DOWN alert, with the acknowledgement link when the strategy supports one
+func downAlertSender(ctx context.Context, target *Target, result *CheckResult, ackURL string) func(strategy AlertStrategy) error {
+	return func(strategy AlertStrategy) error {
+		if ackSender, ok := strategy.(AcknowledgementAwareAlert); ok && ackURL != "" {
+			return ackSender.SendAlertWithAck(ctx, target, result, ackURL)
+		}
+		return strategy.SendAlert(ctx, target, result)
+	}
+}
+
+// scheduledAlertStrategies drops the strategies whose notifier is outside its
+// active_hours or inside its quiet_hours right now
+func (e *TargetEngine) scheduledAlertStrategies(state *TargetState, event string, strategies []AlertStrategy) []AlertStrategy {
+	if len(e.notifierSchedules) == 0 {
+		return strategies
+	}
+	now := time.Now()
+	allowed := make([]AlertStrategy, 0, len(strategies))
+	for _, strategy := range strategies {
+		if schedule, exists := e.notifierSchedules[strategy]; exists && !schedule.Allows(event, now) {
+			logEvent("alert.quiet_hours", state.Target.Name, "Skipping %s alert for %s via %s: notifier is in quiet hours", event, state.Target.Name, strategy.Name())
+			continue
+		}
+		allowed = append(allowed, strategy)
+	}
+	return allowed
+}
+
+// slowAlertStrategies resolves the target's slow route, slow_alerts or regular alerts to
+// the strategies that can report SLOW; others are skipped with a log line
+func (e *TargetEngine) slowAlertStrategies(state *TargetState) []SlowResponseAwareAlert {
+	strategies := e.routedAlertStrategies(state, "slow")
+	var slowAware []SlowResponseAwareAlert
+	for _, strat := range strategies {
+		if slowSender, ok := strat.(SlowResponseAwareAlert); ok {
+			slowAware = append(slowAware, slowSender)
+		} else {
+			log.Printf("Skipping SLOW alert for %s via %s: strategy does not support slow alerts", state.Target.Name, strat.Name())
+		}
+	}
+	return slowAware
+}
+
+// downDependency returns the root-cause dependency (direct or transitive) of the target
+// that is currently down, or nil. Cycles in depends_on are ignored.
+func (e *TargetEngine) downDependency(state *TargetState) *TargetState {
+	visited := map[string]bool{state.Target.Name: true}
+	var walk func(names []string) *TargetState
+	walk = func(names []string) *TargetState {
+		for _, name := range names {
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			dep := e.FindTargetByName(name)
+			if dep == nil {
+				continue
+			}
+			// Prefer the deepest down dependency so the message names the root cause
+			if root := walk(dep.Target.DependsOn); root != nil {
+				return root
+			}
+			if dep.IsDown {
+				return dep
+			}
+		}
+		return nil
+	}
+	return walk(state.Target.DependsOn)
+}
+
+// noteSuppressedDependent records that a dependent's alert was suppressed by dep's outage,
+// once its own threshold was reached. The dependent is covered by the next
+// sendDependencyNotes rather than alerting on its own.
+func (e *TargetEngine) noteSuppressedDependent(dep *TargetState, dependent *TargetState) {
+	dep.dependentsMutex.Lock()
+	defer dep.dependentsMutex.Unlock()
+	if slices.Contains(dep.SuppressedDependents, dependent.Target.Name) {
+		return
+	}
+	dep.SuppressedDependents = append(dep.SuppressedDependents, dependent.Target.Name)
+	dep.PendingDependents = append(dep.PendingDependents, dependent.Target.Name)
+	logEvent("alert.suppressed", dependent.Target.Name, "Suppressing DOWN alert for %s: dependency %s is down", dependent.Target.Name, dep.Target.Name)
+}
+
+// sendDependencyNotes sends one "dependency down" note per alert for the dependents of dep
+// suppressed since the last note. Each dependent is announced on its own DOWN routing, and
+// an alert shared by several dependents gets a single note naming all of them.
+func (e *TargetEngine) sendDependencyNotes(ctx context.Context, dep *TargetState) {
+	dep.dependentsMutex.Lock()
+	pending := dep.PendingDependents
+	dep.PendingDependents = nil
+	dep.dependentsMutex.Unlock()
+
+	var strategies []AlertStrategy
+	dependents := make(map[AlertStrategy][]string)
+	for _, name := range pending {
+		dependent := e.FindTargetByName(name)
+		if dependent == nil {
+			continue
+		}
+		for _, strat := range e.routedAlertStrategies(dependent, "down") {
+			if _, seen := dependents[strat]; !seen {
+				strategies = append(strategies, strat)
+			}
+			dependents[strat] = append(dependents[strat], name)
+		}
+	}
+
+	// The note is about dep's outage; dep's own alert_message_template doesn't apply
+	noteTarget := *dep.Target
+	noteTarget.AlertMessageTemplate = ""
+	for _, strat := range strategies {
+		names := dependents[strat]
+		result := &CheckResult{
+			Success:   false,
+			Error:     fmt.Sprintf("dependency %s is down; suppressing DOWN alerts for %d dependent target(s): %s", dep.Target.Name, len(names), strings.Join(names, ", ")),
+			Timestamp: time.Now(),
+		}
+		if dep.LastCheck != nil {
+			result.StatusCode = dep.LastCheck.StatusCode
+			result.ResponseTime = dep.LastCheck.ResponseTime
+		}
+		e.deliver(ctx, &noteTarget, strat, func(s AlertStrategy) error {
+			return s.SendAlert(ctx, &noteTarget, result)
+		})
+
+		e.metrics.mutex.Lock()
+		e.metrics.AlertsSent++
+		e.metrics.AlertsSentTotal++
+		e.metrics.mutex.Unlock()
+	}
+}
+
+// clearSuppressedDependents forgets the dependents suppressed by a target's outage, once
+// it recovers
+func clearSuppressedDependents(state *TargetState) {
+	state.dependentsMutex.Lock()
+	state.SuppressedDependents = nil
+	state.PendingDependents = nil
+	state.dependentsMutex.Unlock()
 }
 
 // GetTargetByName finds a target by name or URL
 func (e *TargetEngine) GetTargetByName(name string) *TargetState {
-	for _, state := range e.targets {
+	for _, state := range e.targetStates() {
 		if state.Target.Name == name || state.Target.URL == name {
 			return state
 		}
@@ -858,6 +2290,7 @@ type StatusReportData struct {
 type ActiveOutageInfo struct {
 	TargetName     string
 	TargetURL      string
+	TargetTags     []string
 	DownSince      time.Time
 	Duration       time.Duration
 	Acknowledged   bool
@@ -881,11 +2314,12 @@ func (e *TargetEngine) GenerateStatusReport() *StatusReportData {
 	}
 
 	// Collect active outages
-	for _, state := range e.targets {
+	for _, state := range e.targetStates() {
 		if state.IsDown && state.DownSince != nil {
 			outage := ActiveOutageInfo{
 				TargetName:   state.Target.Name,
 				TargetURL:    state.Target.URL,
+				TargetTags:   state.Target.Tags,
 				DownSince:    *state.DownSince,
 				Duration:     time.Since(*state.DownSince),
 				Acknowledged: state.AcknowledgedAt != nil,
@@ -914,30 +2348,182 @@ func (e *TargetEngine) GenerateStatusReport() *StatusReportData {
 	return report
 }
 
+// ForTag returns a copy of the report covering only outages of targets tagged tag.
+// Alert and notification counts are not tracked per target and stay global.
+func (r *StatusReportData) ForTag(tag string) *StatusReportData {
+	scoped := *r
+	scoped.ActiveOutages = make([]ActiveOutageInfo, 0)
+	for _, outage := range r.ActiveOutages {
+		if hasTag(outage.TargetTags, tag) {
+			scoped.ActiveOutages = append(scoped.ActiveOutages, outage)
+		}
+	}
+	scoped.ResolvedOutages = make([]ResolvedOutage, 0)
+	for _, resolved := range r.ResolvedOutages {
+		if hasTag(resolved.TargetTags, tag) {
+			scoped.ResolvedOutages = append(scoped.ResolvedOutages, resolved)
+		}
+	}
+	return &scoped
+}
+
+// hasTag reports whether tags contains tag, ignoring case
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultCheckHistorySize is how many checks a target keeps when check_history_size is unset
+const defaultCheckHistorySize = 1000
+
+// maxCheckHistorySize caps check_history_size; entries can hold up to max_body_store_kb of body each
+const maxCheckHistorySize = 100000
+
+// HistoryLimit returns the most entries kept in the target's check history
+func (s *TargetState) HistoryLimit() int {
+	if s.HistorySize <= 0 {
+		return defaultCheckHistorySize
+	}
+	return s.HistorySize
+}
+
 // AddCheckHistory adds a check result to the target's history
 func (s *TargetState) AddCheckHistory(entry CheckHistoryEntry) {
 	s.historyMutex.Lock()
-	defer s.historyMutex.Unlock()
-
 	s.CheckHistory = append(s.CheckHistory, entry)
 
-	// Keep only the last 1000 entries
-	if len(s.CheckHistory) > 1000 {
-		s.CheckHistory = s.CheckHistory[len(s.CheckHistory)-1000:]
+	// Drop entries older than the retention age, then keep only the last HistoryLimit entries
+	s.CheckHistory = s.CheckHistory[s.firstRetainedIndex():]
+	if limit := s.HistoryLimit(); len(s.CheckHistory) > limit {
+		s.CheckHistory = s.CheckHistory[len(s.CheckHistory)-limit:]
+	}
+	s.historyMutex.Unlock()
+
+	if s.historyStore != nil {
+		if err := s.historyStore.RecordCheck(s.Target.URL, entry, s.HistoryLimit(), s.HistoryMaxAge); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// CheckHistoryBetween returns the retained checks from since to until (zero times leave
+// that end open), read from the database when state is kept in SQLite
+func (s *TargetState) CheckHistoryBetween(since, until time.Time) ([]CheckHistoryEntry, error) {
+	if s.historyStore != nil {
+		return s.historyStore.CheckHistory(s.Target.URL, since, until, s.HistoryLimit())
+	}
+	history := []CheckHistoryEntry{}
+	for _, entry := range s.GetCheckHistory() {
+		if (since.IsZero() || !entry.Timestamp.Before(since)) && (until.IsZero() || !entry.Timestamp.After(until)) {
+			history = append(history, entry)
+		}
+	}
+	return history, nil
+}
+
+// firstRetainedIndex returns the index of the oldest entry within the retention age;
+// history is chronological, so everything before it has expired. Callers hold historyMutex.
+func (s *TargetState) firstRetainedIndex() int {
+	if s.HistoryMaxAge <= 0 {
+		return 0
 	}
+	cutoff := time.Now().Add(-s.HistoryMaxAge)
+	return sort.Search(len(s.CheckHistory), func(i int) bool {
+		return !s.CheckHistory[i].Timestamp.Before(cutoff)
+	})
 }
 
-// GetCheckHistory safely retrieves the check history
+// GetCheckHistory safely retrieves the check history within the retention age
 func (s *TargetState) GetCheckHistory() []CheckHistoryEntry {
 	s.historyMutex.RLock()
 	defer s.historyMutex.RUnlock()
 
-	// Return a copy to avoid race conditions
-	history := make([]CheckHistoryEntry, len(s.CheckHistory))
-	copy(history, s.CheckHistory)
+	// Return a copy to avoid race conditions; expired entries are skipped so stats
+	// windows match retention even when no new checks have pruned them yet
+	retained := s.CheckHistory[s.firstRetainedIndex():]
+	if limit := s.HistoryLimit(); len(retained) > limit {
+		retained = retained[len(retained)-limit:]
+	}
+	history := make([]CheckHistoryEntry, len(retained))
+	copy(history, retained)
 	return history
 }
 
+// UptimeWindow is the share of successful checks within a trailing window of check history
+type UptimeWindow struct {
+	Window     string   `json:"window"`
+	Checks     int      `json:"checks"`
+	Successful int      `json:"successful"`
+	Percent    *float64 `json:"percent"`        // nil when no checks fall inside the window
+	Complete   bool     `json:"complete"`       // false when retained history starts after the window does
+	Span       string   `json:"span,omitempty"` // for an incomplete window, how far back retained history goes
+}
+
+// uptimeWindowDurations are the windows reported on the detail page and /api/history/
+var uptimeWindowDurations = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// calculateUptime returns successful/total checks for each uptime window ending at now.
+// history is chronological, as returned by GetCheckHistory. The first window retained
+// history does not cover is the last one returned, since longer windows would only
+// repeat its figure.
+func calculateUptime(history []CheckHistoryEntry, now time.Time) []UptimeWindow {
+	windows := make([]UptimeWindow, 0, len(uptimeWindowDurations))
+	for _, w := range uptimeWindowDurations {
+		cutoff := now.Add(-w.duration)
+		uptime := UptimeWindow{Window: w.label}
+		for _, entry := range history {
+			if entry.Timestamp.Before(cutoff) {
+				continue
+			}
+			uptime.Checks++
+			if entry.Success {
+				uptime.Successful++
+			}
+		}
+		if uptime.Checks > 0 {
+			percent := float64(uptime.Successful) / float64(uptime.Checks) * 100
+			uptime.Percent = &percent
+		}
+		uptime.Complete = len(history) > 0 && !history[0].Timestamp.After(cutoff)
+		if !uptime.Complete && len(history) > 0 {
+			uptime.Span = formatDuration(now.Sub(history[0].Timestamp))
+		}
+		windows = append(windows, uptime)
+		if !uptime.Complete {
+			break
+		}
+	}
+	return windows
+}
+
+// String formats the uptime percentage for display, or "N/A" with no checks
+func (u UptimeWindow) String() string {
+	if u.Percent == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", *u.Percent)
+}
+
+// Label names the period the figure covers: the window, or the retained span when
+// history does not reach back that far
+func (u UptimeWindow) Label() string {
+	if u.Span == "" {
+		return u.Window
+	}
+	return "last " + u.Span
+}
+
 // GetURLSafeName returns a URL-safe version of the target name
 func (s *TargetState) GetURLSafeName() string {
 	return ToURLSafe(s.Target.Name)
@@ -972,7 +2558,7 @@ func ToURLSafe(name string) string {
 
 // FindTargetByName finds a target by its name
 func (e *TargetEngine) FindTargetByName(name string) *TargetState {
-	for _, state := range e.targets {
+	for _, state := range e.targetStates() {
 		if state.Target.Name == name {
 			return state
 		}
@@ -982,7 +2568,7 @@ func (e *TargetEngine) FindTargetByName(name string) *TargetState {
 
 // FindTargetByURLSafeName finds a target by its URL-safe name
 func (e *TargetEngine) FindTargetByURLSafeName(urlSafeName string) *TargetState {
-	for _, state := range e.targets {
+	for _, state := range e.targetStates() {
 		if state.GetURLSafeName() == urlSafeName {
 			return state
 		}
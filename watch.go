@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	qc "github.com/bevelwork/quick_color"
+)
+
+// clearScreen moves the cursor home and clears the terminal before each redraw
+const clearScreen = "\033[H\033[2J"
+
+// statusSource returns the current state of every target for `list --watch`
+type statusSource func(ctx context.Context) ([]*TargetState, error)
+
+// handleListWatch redraws the status table every --interval seconds until interrupted. It
+// reads the running server's /api/status, or with --local checks the state file's targets
+// itself (without alerting).
+func handleListWatch(args []string) {
+	interval := time.Duration(getIntFlag(args, "--interval", 5)) * time.Second
+	if interval <= 0 {
+		fmt.Printf("%s --interval must be a positive number of seconds\n", qc.Colorize("❌ Error:", qc.ColorRed))
+		os.Exit(1)
+	}
+	stateManager := NewStateManager(getStateFile(args))
+	if err := stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load existing state: %v", err)
+	}
+	settings := stateManager.GetSettings()
+	configureTimezone(settings)
+
+	var source statusSource
+	var origin string
+	if slices.Contains(args, "--local") {
+		configureProxy(settings)
+		source = localStatusSource(stateManager.GetTargetConfig().Targets, newCheckStrategies(settings))
+		origin = "local checks"
+	} else {
+		endpoint := runningServerURL(settings, getStringFlag(args, "--server", "")) + "/api/status"
+		source = func(ctx context.Context) ([]*TargetState, error) {
+			return fetchTargetStatus(ctx, endpoint, settings)
+		}
+		origin = endpoint
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		targets, err := source(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Print(clearScreen)
+		fmt.Printf("%s %s, every %s (Ctrl-C to quit)\n\n", qc.Colorize("👀 Watching:", qc.ColorCyan), origin, interval)
+		if err != nil {
+			fmt.Printf("%s %v\n", qc.Colorize("❌ Error:", qc.ColorRed), err)
+		} else {
+			fmt.Print(renderStatusTable(targets, true))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchTargetStatus reads the targets' current state from a running server's /api/status
+func fetchTargetStatus(ctx context.Context, endpoint string, settings ServerSettings) ([]*TargetState, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server address: %v", err)
+	}
+	setAPIAuthHeader(req, settings)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach server at %s: %v (use --local to check targets directly)", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d for %s", resp.StatusCode, endpoint)
+	}
+
+	var status struct {
+		Targets []struct {
+			Name           string       `json:"name"`
+			URL            string       `json:"url"`
+			Paused         bool         `json:"paused"`
+			IsDown         bool         `json:"is_down"`
+			DownSince      *time.Time   `json:"down_since"`
+			AcknowledgedAt *time.Time   `json:"acknowledged_at"`
+			LastCheck      *CheckResult `json:"last_check"`
+		} `json:"targets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode status: %v", err)
+	}
+	targets := make([]*TargetState, 0, len(status.Targets))
+	for _, t := range status.Targets {
+		targets = append(targets, &TargetState{
+			Target:         &Target{Name: t.Name, URL: t.URL, Paused: t.Paused},
+			IsDown:         t.IsDown,
+			DownSince:      t.DownSince,
+			AcknowledgedAt: t.AcknowledgedAt,
+			LastCheck:      t.LastCheck,
+		})
+	}
+	return targets, nil
+}
+
+// localStatusSource checks targets once per call, keeping when each started failing so the
+// table can show downtime. A failing check counts as down immediately; thresholds and
+// alerts are left to the server.
+func localStatusSource(targets []Target, strategies map[string]CheckStrategy) statusSource {
+	states := make([]*TargetState, len(targets))
+	for i := range targets {
+		states[i] = &TargetState{Target: &targets[i]}
+	}
+	return func(ctx context.Context) ([]*TargetState, error) {
+		for i, result := range runChecksOnce(ctx, targets, strategies, defaultCheckConcurrency) {
+			state := states[i]
+			if result.Skipped || result.Result == nil {
+				continue
+			}
+			state.LastCheck = result.Result
+			state.IsDown = !result.Result.Success
+			if !state.IsDown {
+				state.DownSince = nil
+			} else if state.DownSince == nil {
+				state.DownSince = &result.Result.Timestamp
+			}
+		}
+		return states, nil
+	}
+}
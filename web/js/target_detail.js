@@ -5,12 +5,15 @@
 const chartDataElement = document.body;
 const chartData = JSON.parse(chartDataElement.dataset.chartData || '[]');
 const checkStrategy = chartDataElement.dataset.checkStrategy || 'http';
+// settings.timezone; undefined keeps times in the browser's zone
+const displayTimeZone = chartDataElement.dataset.timezone || undefined;
 const isPageComparison = checkStrategy === 'page-comparison';
 
 // Format labels for display
 const labels = chartData.map(d => {
     const date = new Date(d.timestamp);
     return date.toLocaleTimeString('en-US', { 
+        timeZone: displayTimeZone,
         hour: '2-digit', 
         minute: '2-digit', 
         second: '2-digit',
@@ -101,7 +104,7 @@ const chart = new Chart(ctx, {
                         const idx = context[0].dataIndex;
                         const data = window.chartData || chartData;
                         const date = new Date(data[idx].timestamp);
-                        return date.toLocaleString();
+                        return date.toLocaleString(undefined, { timeZone: displayTimeZone });
                     },
                     label: function(context) {
                         const idx = context.dataIndex;
@@ -332,6 +335,7 @@ function updateChart(history) {
     const newLabels = newData.map(d => {
         const date = new Date(d.timestamp);
         return date.toLocaleTimeString('en-US', { 
+            timeZone: displayTimeZone,
             hour: '2-digit', 
             minute: '2-digit', 
             second: '2-digit',
@@ -424,7 +428,7 @@ function updateLogEntries(history) {
         // Build expanded content
         let expandedLines = [];
         const timestamp = new Date(entry.Timestamp);
-        expandedLines.push('Timestamp: ' + timestamp.toLocaleString() + ' ' + (timestamp.toString().match(/\(([^)]+)\)$/)?.[1] || ''));
+        expandedLines.push('Timestamp: ' + timestamp.toLocaleString(undefined, { timeZone: displayTimeZone, timeZoneName: 'short' }));
         if (entry.StatusCode > 0) expandedLines.push('Status Code: ' + entry.StatusCode);
         if (entry.Success) {
             const seconds = entry.ResponseTime / 1000.0;
@@ -495,7 +499,7 @@ function updateLogEntries(history) {
         const expandClass = isExpanded ? ' expanded' : '';
         const displayStyle = isExpanded ? 'block' : 'none';
         
-        const entryTime = timestamp.toLocaleTimeString('en-US', { hour: '2-digit', minute: '2-digit', second: '2-digit', hour12: false });
+        const entryTime = timestamp.toLocaleTimeString('en-US', { timeZone: displayTimeZone, hour: '2-digit', minute: '2-digit', second: '2-digit', hour12: false });
         
         newHTML += '<div class="log-entry-wrapper">';
         newHTML += '<div class="log-entry ' + statusClass + '" onclick="toggleEntry(' + entryID + ')">';
@@ -525,5 +529,28 @@ function escapeHtml(text) {
 // Make chartData global for tooltip callbacks
 window.chartData = chartData;
 
-// Start auto-update
-setInterval(updateData, 5000);
+// Update as soon as this target reports a result (live via
+// /api/events); fall back to polling every 5 seconds
+const targetPath = window.location.pathname.replace('/targets/', '');
+let pollTimer = null;
+function startPolling() {
+    if (!pollTimer) {
+        pollTimer = setInterval(updateData, 5000);
+    }
+}
+
+if (window.EventSource) {
+    const events = new EventSource('/api/events');
+    events.addEventListener('check', (e) => {
+        const event = JSON.parse(e.data);
+        if (event.url_safe === targetPath) {
+            updateData();
+        }
+    });
+    events.onerror = () => {
+        events.close();
+        startPolling();
+    };
+} else {
+    startPolling();
+}
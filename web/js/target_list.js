@@ -3,14 +3,19 @@ let filterTimeout;
 
 function filterTargets() {
     const filterValue = document.getElementById('filterInput').value.toLowerCase();
+    const tagValue = document.getElementById('tagFilter').value;
     const cards = document.querySelectorAll('.target-card');
     let visibleCount = 0;
     
     cards.forEach(card => {
         const name = card.getAttribute('data-target-name');
         const url = card.getAttribute('data-target-url');
+        const tags = card.getAttribute('data-target-tags');
+        const tagList = tags ? tags.split(',') : [];
         
-        if (name.includes(filterValue) || url.includes(filterValue)) {
+        const matchesText = name.includes(filterValue) || url.includes(filterValue) || tags.includes(filterValue);
+        const matchesTag = !tagValue || tagList.includes(tagValue);
+        if (matchesText && matchesTag) {
             card.classList.remove('hidden');
             visibleCount++;
         } else {
@@ -20,7 +25,7 @@ function filterTargets() {
     
     // Update count
     const filterCount = document.getElementById('filterCount');
-    if (filterValue) {
+    if (filterValue || tagValue) {
         filterCount.textContent = visibleCount + ' of ' + cards.length + ' targets';
         filterCount.style.display = 'inline';
     } else {
@@ -30,18 +35,60 @@ function filterTargets() {
 
 function clearFilter() {
     document.getElementById('filterInput').value = '';
-    filterTargets();
+    document.getElementById('tagFilter').value = '';
+    filterByTag();
     document.getElementById('filterInput').focus();
 }
 
-// Auto-refresh every 5 seconds (but don't reload if filtering)
-setTimeout(() => {
+// Keep the selected tag in the URL so reloads and bookmarks keep the view
+function filterByTag() {
+    const tagValue = document.getElementById('tagFilter').value;
+    const url = new URL(window.location.href);
+    if (tagValue) {
+        url.searchParams.set('tag', tagValue);
+    } else {
+        url.searchParams.delete('tag');
+    }
+    history.replaceState(null, '', url);
+    filterTargets();
+}
+
+document.addEventListener('DOMContentLoaded', filterTargets);
+
+// Reload immediately when a target goes up or down (live via
+// /api/events); fall back to polling every 5 seconds when SSE isn't available
+function reloadUnlessFiltering() {
     const filterValue = document.getElementById('filterInput').value;
     if (!filterValue) {
         window.location.reload();
     } else {
         // If filtering, just refresh after clearing filter
-        setTimeout(() => window.location.reload(), 5000);
+        setTimeout(reloadUnlessFiltering, 5000);
     }
-}, 5000);
+}
+
+function startPolling() {
+    setTimeout(reloadUnlessFiltering, 5000);
+}
+
+// Fresh check results refresh the cards at most every 5 seconds
+let refreshPending = false;
+function scheduleRefresh() {
+    if (!refreshPending) {
+        refreshPending = true;
+        setTimeout(reloadUnlessFiltering, 5000);
+    }
+}
+
+if (window.EventSource) {
+    const events = new EventSource('/api/events');
+    events.addEventListener('state', reloadUnlessFiltering);
+    events.addEventListener('check', scheduleRefresh);
+    events.onerror = () => {
+        events.close();
+        startPolling();
+    };
+} else {
+    startPolling();
+}
 
@@ -60,10 +60,13 @@ func (w *WebhookServer) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop stops the webhook server
+// Stop stops the webhook server, forcing connections closed if ctx expires first
 func (w *WebhookServer) Stop(ctx context.Context) error {
 	if w.server != nil {
-		return w.server.Shutdown(ctx)
+		if err := w.server.Shutdown(ctx); err != nil {
+			w.server.Close()
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// webhookCallbackTimeout bounds each callback request
+const webhookCallbackTimeout = 10 * time.Second
+
+// WebhookCallback is an outbound request a webhook target makes when it is triggered and
+// when it recovers, e.g. to confirm a downtime window to the system that opened it
+type WebhookCallback struct {
+	URL          string            `json:"url" yaml:"url"`
+	Method       string            `json:"method,omitempty" yaml:"method,omitempty"` // default: POST
+	Headers      map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	BodyTemplate string            `json:"body_template,omitempty" yaml:"body_template,omitempty"` // Go template over webhookCallbackData; default: a JSON summary (not sent with GET)
+}
+
+// webhookCallbackData is what a callback body_template can reference
+type webhookCallbackData struct {
+	Event     string // "triggered" or "recovered"
+	Target    string
+	URL       string
+	Message   string // trigger message; empty on recovery
+	Timestamp string // RFC 3339
+	RecoverAt string // RFC 3339 auto-recovery time of a triggered target; empty without a duration
+}
+
+// parseCallbackTemplate compiles the callback's body_template, or returns nil when it has none
+func parseCallbackTemplate(callback *WebhookCallback) (*template.Template, error) {
+	if strings.TrimSpace(callback.BodyTemplate) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("callback").Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(callback.BodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body_template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// validateWebhookCallback checks a webhook target's callback before it is saved
+func validateWebhookCallback(callback *WebhookCallback) error {
+	if !strings.HasPrefix(callback.URL, "http://") && !strings.HasPrefix(callback.URL, "https://") {
+		return fmt.Errorf("url must start with http:// or https://, got '%s'", callback.URL)
+	}
+	switch strings.ToUpper(callback.Method) {
+	case "", http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("method must be GET, POST, PUT, PATCH or DELETE, got '%s'", callback.Method)
+	}
+	_, err := parseCallbackTemplate(callback)
+	return err
+}
+
+// sendWebhookCallback runs the target's callback for event in the background; failures are
+// logged rather than affecting the trigger or recovery
+func (e *TargetEngine) sendWebhookCallback(state *TargetState, event, message string) {
+	callback := state.Target.Callback
+	if callback == nil || callback.URL == "" {
+		return
+	}
+	data := webhookCallbackData{
+		Event:     event,
+		Target:    state.Target.Name,
+		URL:       state.Target.URL,
+		Message:   message,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	if state.RecoveryTime != nil {
+		data.RecoverAt = state.RecoveryTime.Format(time.RFC3339)
+	}
+	go func() {
+		if err := postWebhookCallback(callback, data); err != nil {
+			logEvent("callback.failed", data.Target, "Callback for %s (%s) failed: %v", data.Target, event, err)
+			return
+		}
+		logEvent("callback.sent", data.Target, "Callback for %s (%s) sent to %s", data.Target, event, callback.URL)
+	}()
+}
+
+// postWebhookCallback renders and sends one callback request
+func postWebhookCallback(callback *WebhookCallback, data webhookCallbackData) error {
+	tmpl, err := parseCallbackTemplate(callback)
+	if err != nil {
+		return err
+	}
+	var body []byte
+	if tmpl != nil {
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return fmt.Errorf("failed to render body_template: %v", err)
+		}
+		body = rendered.Bytes()
+	} else {
+		payload := map[string]any{
+			"event":     data.Event,
+			"target":    data.Target,
+			"url":       data.URL,
+			"timestamp": data.Timestamp,
+		}
+		if data.Message != "" {
+			payload["message"] = data.Message
+		}
+		if data.RecoverAt != "" {
+			payload["recover_at"] = data.RecoverAt
+		}
+		if body, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("failed to marshal callback payload: %v", err)
+		}
+	}
+
+	method := strings.ToUpper(callback.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookCallbackTimeout)
+	defer cancel()
+	var reader io.Reader
+	if method != http.MethodGet {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, callback.URL, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create callback request: %v", err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range callback.Headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Transport: newOutboundTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send callback: %v", maskURLError(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return nil
+}
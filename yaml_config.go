@@ -1,6 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,6 +24,7 @@ type YAMLConfig struct {
 func (yc *YAMLConfig) ConvertToTargetConfig() *TargetConfig {
 	targets := make([]Target, 0, len(yc.Targets))
 	for _, target := range yc.Targets {
+		applyRetryOnFailureAlias(&target)
 		targets = append(targets, target)
 	}
 
@@ -30,8 +39,24 @@ func (yc *YAMLConfig) ConvertToTargetConfig() *TargetConfig {
 	return config
 }
 
-// LoadYAMLConfig loads configuration from YAML data
+// LoadYAMLConfig loads configuration from YAML data; relative include paths resolve
+// against the working directory
 func LoadYAMLConfig(data []byte) (*TargetConfig, error) {
+	return LoadYAMLConfigFrom(data, ".")
+}
+
+// LoadYAMLConfigFrom loads configuration from YAML data read from a file in baseDir,
+// which relative include paths resolve against
+func LoadYAMLConfigFrom(data []byte, baseDir string) (*TargetConfig, error) {
+	data, err := resolveIncludesAndDefaults(data, baseDir)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err = expandEnvYAML(data)
+	if err != nil {
+		return nil, err
+	}
+
 	// First try new schema (targets)
 	var yamlConfig YAMLConfig
 	if err := yaml.Unmarshal(data, &yamlConfig); err != nil {
@@ -53,3 +78,253 @@ func LoadYAMLConfig(data []byte) (*TargetConfig, error) {
 
 	return yamlConfig.ConvertToTargetConfig(), nil
 }
+
+// resolveIncludesAndDefaults merges the files listed under a top-level include: key into
+// the document (its own values win), then merges the defaults: block into every target
+// (the target's values win). Nested maps such as headers merge key by key; lists and
+// scalars are replaced. Documents with neither key are returned unchanged.
+func resolveIncludesAndDefaults(data []byte, baseDir string) ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	_, hasInclude := doc["include"]
+	_, hasDefaults := doc["defaults"]
+	if !hasInclude && !hasDefaults {
+		return data, nil
+	}
+
+	doc, err := mergeIncludes(doc, baseDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTargetDefaults(doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// mergeIncludes returns doc layered over the files it includes, in order; seen holds
+// the files already being included, to reject cycles
+func mergeIncludes(doc map[string]any, baseDir string, seen []string) (map[string]any, error) {
+	var paths []string
+	switch include := doc["include"].(type) {
+	case nil:
+		return doc, nil
+	case string:
+		paths = []string{include}
+	case []any:
+		for _, item := range include {
+			path, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("include: entries must be file paths, got %v", item)
+			}
+			paths = append(paths, path)
+		}
+	default:
+		return nil, fmt.Errorf("include: must be a file path or a list of file paths")
+	}
+	delete(doc, "include")
+
+	merged := map[string]any{}
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		path = filepath.Clean(path)
+		if slices.Contains(seen, path) {
+			return nil, fmt.Errorf("include: %s includes itself", path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("include: %v", err)
+		}
+		var included map[string]any
+		if err := yaml.Unmarshal(data, &included); err != nil {
+			return nil, fmt.Errorf("include %s: %v", path, err)
+		}
+		included, err = mergeIncludes(included, filepath.Dir(path), append(seen, path))
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeYAMLMaps(merged, included)
+	}
+	return mergeYAMLMaps(merged, doc), nil
+}
+
+// applyTargetDefaults merges doc's defaults: block into each entry under targets:
+func applyTargetDefaults(doc map[string]any) error {
+	raw, ok := doc["defaults"]
+	if !ok {
+		return nil
+	}
+	delete(doc, "defaults")
+	defaults, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Errorf("defaults: must be a map of target fields")
+	}
+	for _, field := range []string{"url", "name"} {
+		if _, set := defaults[field]; set {
+			return fmt.Errorf("defaults: %s must be set on each target", field)
+		}
+	}
+
+	switch targets := doc["targets"].(type) {
+	case map[string]any:
+		for key, target := range targets {
+			if targetMap, ok := target.(map[string]any); ok {
+				targets[key] = mergeYAMLMaps(defaults, targetMap)
+			}
+		}
+	case []any:
+		for i, target := range targets {
+			if targetMap, ok := target.(map[string]any); ok {
+				targets[i] = mergeYAMLMaps(defaults, targetMap)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeYAMLMaps returns base overlaid with override, merging nested maps recursively
+func mergeYAMLMaps(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeYAMLMaps(baseMap, overrideMap)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// envReferencePattern matches ${NAME} and ${NAME:-default}; $${ escapes a literal ${
+var envReferencePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnvString replaces ${NAME} references in value with environment variables.
+// Unset variables without a :-default are an error.
+func expandEnvString(value string) (string, error) {
+	var missing []string
+	expanded := envReferencePattern.ReplaceAllStringFunc(value, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		match := envReferencePattern.FindStringSubmatch(ref)
+		if envValue, ok := os.LookupEnv(match[1]); ok {
+			return envValue
+		}
+		if match[2] != "" {
+			return match[3]
+		}
+		missing = append(missing, match[1])
+		return ref
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set (use ${%s:-default} for a fallback)", missing[0], missing[0])
+	}
+	return expanded, nil
+}
+
+// expandEnvYAML expands ${NAME} references in every scalar value of a YAML document.
+// It returns the expanded document and the original text of each expanded value keyed
+// by its path (see yamlNodePath), so the references can be restored when saving.
+func expandEnvYAML(data []byte) ([]byte, map[string]string, error) {
+	if !strings.Contains(string(data), "${") {
+		return data, nil, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+	originals := make(map[string]string)
+	var walkErr error
+	walkYAMLScalars(&root, "", func(node *yaml.Node, path string) {
+		if walkErr != nil || !strings.Contains(node.Value, "${") {
+			return
+		}
+		expanded, err := expandEnvString(node.Value)
+		if err != nil {
+			walkErr = fmt.Errorf("%s: %v", path, err)
+			return
+		}
+		originals[path] = node.Value
+		node.Value = expanded
+		// Let plain scalars re-resolve so "${SMTP_PORT}" can become an int
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 {
+			node.Tag = ""
+		}
+	})
+	if walkErr != nil {
+		return nil, nil, walkErr
+	}
+	expanded, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return expanded, originals, nil
+}
+
+// restoreEnvYAML puts ${NAME} references back into a marshaled document for values
+// that still hold what the reference expanded to when the state was loaded
+func restoreEnvYAML(data []byte, originals map[string]string) ([]byte, error) {
+	if len(originals) == 0 {
+		return data, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	restored := false
+	walkYAMLScalars(&root, "", func(node *yaml.Node, path string) {
+		original, ok := originals[path]
+		if !ok {
+			return
+		}
+		if expanded, err := expandEnvString(original); err != nil || expanded != node.Value {
+			return
+		}
+		node.Value = original
+		node.Tag = "!!str"
+		node.Style = 0
+		restored = true
+	})
+	if !restored {
+		return data, nil
+	}
+	return yaml.Marshal(&root)
+}
+
+// walkYAMLScalars calls fn for every scalar value under node with a dotted path of
+// mapping keys and sequence indexes (e.g. "alerts.slack.settings.webhook_url")
+func walkYAMLScalars(node *yaml.Node, path string, fn func(node *yaml.Node, path string)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkYAMLScalars(child, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkYAMLScalars(node.Content[i+1], yamlNodePath(path, node.Content[i].Value), fn)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walkYAMLScalars(child, yamlNodePath(path, strconv.Itoa(i)), fn)
+		}
+	case yaml.ScalarNode:
+		fn(node, path)
+	}
+}
+
+// yamlNodePath appends a key to a dotted YAML path
+func yamlNodePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}